@@ -1,28 +1,71 @@
-// Package cleaner is for the periodic database cleaner binary
-package cleaner
+// The cleaner binary is the maintenance daemon: it purges expired trash,
+// jars, tokens and abandoned scrolls, and deletes the storage objects of
+// deleted scrolls, each task on an interval of its own.
+package main
 
 import (
 	"context"
 	"flag"
 	"os"
-	"strings"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/kapilpokhrel/scrolljar/internal/database"
 	"github.com/kapilpokhrel/scrolljar/internal/logger"
 )
 
+// leaderLockKey is the advisory lock held by the cleaner that runs the tasks.
+// Other cleaners wait on standby and take over if it goes away.
+const leaderLockKey int64 = 0x5c70114a
+
+// leaderRetry is how often a cleaner on standby tries to take over.
+const leaderRetry = 30 * time.Second
+
 type cleanerCfg struct {
-	DBURL        string
-	S3BucketName string
+	DBURL          string
+	S3BucketName   string
+	TrashRetention time.Duration
+	Once           bool
+
+	UnuploadedRetention time.Duration
+
+	// Safety limits for deleting objects that belong to no scroll.
+	DryRun       bool
+	MinObjectAge time.Duration
+	MaxDeletions int
+	ReportPath   string
+
+	TrashInterval         time.Duration
+	ExpiredJarsInterval   time.Duration
+	UnuploadedInterval    time.Duration
+	ExpiredTokensInterval time.Duration
+	OutboxInterval        time.Duration
+	ReconcileInterval     time.Duration
 }
 
 func parseFlags() cleanerCfg {
 	var cfg cleanerCfg
 	flag.StringVar(&cfg.DBURL, "db_url", os.Getenv("SCROLLJAR_DB_URL"), "PostgreSQL URL")
 	flag.StringVar(&cfg.S3BucketName, "s3-bucket", os.Getenv("S3_BUCKET"), "s3 bucket")
+	flag.DurationVar(&cfg.TrashRetention, "trash-retention", database.DefaultTrashRetention, "How long deleted jars and scrolls can be restored")
+	flag.DurationVar(&cfg.UnuploadedRetention, "unuploaded-retention", 48*time.Hour, "How long a scroll can go without being uploaded")
+	flag.BoolVar(&cfg.Once, "once", false, "Run every task once and exit")
+
+	flag.BoolVar(&cfg.DryRun, "dry-run", false, "Only run reconciliation, reporting the objects it would delete without deleting them")
+	flag.DurationVar(&cfg.MinObjectAge, "min-object-age", time.Hour, "How old an object must be before reconciliation deletes it")
+	flag.IntVar(&cfg.MaxDeletions, "max-deletions", 10000, "Most objects a reconciliation deletes before stopping")
+	flag.StringVar(&cfg.ReportPath, "report", "", "File to write a JSON report of each reconciliation to")
+
+	flag.DurationVar(&cfg.TrashInterval, "trash-interval", time.Hour, "How often to purge expired trash (0 disables)")
+	flag.DurationVar(&cfg.ExpiredJarsInterval, "expired-jars-interval", 5*time.Minute, "How often to purge expired jars (0 disables)")
+	flag.DurationVar(&cfg.UnuploadedInterval, "unuploaded-interval", time.Hour, "How often to purge scrolls that were never uploaded (0 disables)")
+	flag.DurationVar(&cfg.ExpiredTokensInterval, "expired-tokens-interval", time.Hour, "How often to purge expired tokens (0 disables)")
+	flag.DurationVar(&cfg.OutboxInterval, "outbox-interval", time.Minute, "How often to delete the storage objects of deleted scrolls (0 disables)")
+	flag.DurationVar(&cfg.ReconcileInterval, "reconcile-interval", 24*time.Hour, "How often to scan the whole bucket for objects that belong to no scroll (0 disables)")
 	flag.Parse()
 	return cfg
 }
@@ -34,13 +77,16 @@ func main() {
 	}
 
 	cfg := parseFlags()
+	if cfg.DryRun {
+		log.Info("dry run: only reconciliation runs, and it deletes nothing")
+	}
 
 	dbPool, err := database.SetupDB(database.DBCFG{URL: cfg.DBURL})
 	if err != nil {
 		log.Error(err.Error())
 		return
 	}
-	store := database.NewStore(dbPool)
+	defer dbPool.Close()
 
 	s3Bucket, err := database.NewS3Bucket(database.S3CFG{BucketName: cfg.S3BucketName})
 	if err != nil {
@@ -48,57 +94,80 @@ func main() {
 		return
 	}
 
-	const batchSize = 1000
-	var batch []types.ObjectIdentifier
-	var scrollIDs []string
+	c := &cleaner{
+		cfg:      cfg,
+		store:    database.NewStore(dbPool),
+		s3Bucket: s3Bucket,
+		log:      log,
+	}
 
-	ctx := context.Background()
-	it := s3Bucket.NewAvilKeyIterator(ctx)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	flush := func() {
-		if len(batch) == 0 {
-			return
-		}
-		existing, err := store.GetExistingScrollIDs(ctx, scrollIDs)
+	if cfg.Once {
+		led, err := c.store.WithAdvisoryLock(ctx, leaderLockKey, c.runOnce)
 		if err != nil {
 			log.Error(err.Error())
-			return
-		}
-		existsMap := make(map[string]bool, len(existing))
-		for _, id := range existing {
-			existsMap[id] = true
+		} else if !led {
+			log.Warn("another cleaner is running; not running the tasks")
 		}
-		var toDelete []types.ObjectIdentifier
-		for _, obj := range batch {
-			scrollID := strings.SplitN(*obj.Key, "/", 2)[1]
-			if !existsMap[scrollID] {
-				toDelete = append(toDelete, obj)
-			}
-		}
-		s3Bucket.DeleteBatch(toDelete)
-		batch, scrollIDs = batch[:0], scrollIDs[:0]
+		return
 	}
 
+	standby := false
 	for {
-		key, ok, err := it.Next(ctx)
-		if err != nil {
+		led, err := c.store.WithAdvisoryLock(ctx, leaderLockKey, func(ctx context.Context) {
+			log.Info("leading the cleaners")
+			c.schedule(ctx)
+		})
+		if err != nil && ctx.Err() == nil {
 			log.Error(err.Error())
-			return
 		}
-		if !ok {
-			break
+		if led {
+			log.Info("stopped leading the cleaners")
+		} else if err == nil && !standby {
+			log.Info("another cleaner is leading; standing by")
+		}
+		standby = !led && err == nil
+		select {
+		case <-time.After(leaderRetry):
+		case <-ctx.Done():
+			return
 		}
+	}
+}
 
-		parts := strings.SplitN(key, "/", 2)
-		if len(parts) < 2 {
+// runOnce runs each enabled task once, in order.
+func (c *cleaner) runOnce(ctx context.Context) {
+	for _, t := range c.tasks() {
+		if !c.enabled(t) || ctx.Err() != nil {
 			continue
 		}
-		scrollIDs = append(scrollIDs, parts[1])
-		batch = append(batch, types.ObjectIdentifier{Key: &key})
+		c.runTask(ctx, t)
+	}
+}
 
-		if len(batch) == batchSize {
-			flush()
+// schedule runs each enabled task right away and then every interval until
+// ctx is done. A task that overruns its interval skips the runs it missed
+// rather than running back to back.
+func (c *cleaner) schedule(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, t := range c.tasks() {
+		if !c.enabled(t) {
+			continue
 		}
+		wg.Go(func() {
+			ticker := time.NewTicker(t.interval)
+			defer ticker.Stop()
+			for {
+				c.runTask(ctx, t)
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
 	}
-	flush()
+	wg.Wait()
 }
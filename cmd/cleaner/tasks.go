@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/jobs"
+)
+
+const (
+	batchSize = 1000
+	// Upload tokens expire within a day, so older uploads are over.
+	uploadRetention = 48 * time.Hour
+)
+
+var errObjectNotDeleted = errors.New("storage refused to delete the object")
+
+type cleaner struct {
+	cfg      cleanerCfg
+	store    *database.Store
+	s3Bucket *database.S3Bucket
+	log      *slog.Logger
+}
+
+// task is a piece of maintenance that runs every interval. It returns a
+// summary of what it did. Tasks that can report what they would delete
+// without deleting it set dryRun.
+type task struct {
+	name     string
+	interval time.Duration
+	dryRun   bool
+	run      func(ctx context.Context) ([]slog.Attr, error)
+}
+
+// tasks lists the cleaner's tasks in the order a single pass runs them, so
+// that the rows deleted by the purges have their objects deleted in the
+// same pass.
+func (c *cleaner) tasks() []task {
+	return []task{
+		{"trash", c.cfg.TrashInterval, false, c.purgeTrash},
+		{"expired-jars", c.cfg.ExpiredJarsInterval, false, c.purgeExpiredJars},
+		{"unuploaded-scrolls", c.cfg.UnuploadedInterval, false, c.purgeUnuploadedScrolls},
+		{"expired-tokens", c.cfg.ExpiredTokensInterval, false, c.purgeExpiredTokens},
+		{"storage-outbox", c.cfg.OutboxInterval, false, c.deleteRecordedObjects},
+		{"reconcile", c.cfg.ReconcileInterval, true, c.reconcileBucket},
+	}
+}
+
+// enabled reports whether a task runs. A dry run skips the tasks that can't
+// be previewed, so that it never deletes anything.
+func (c *cleaner) enabled(t task) bool {
+	return t.interval > 0 && (!c.cfg.DryRun || t.dryRun)
+}
+
+// runTask runs a task once and logs its summary.
+func (c *cleaner) runTask(ctx context.Context, t task) {
+	start := time.Now()
+	summary, err := t.run(ctx)
+	attrs := []slog.Attr{
+		slog.String("task", t.name),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("summary", slog.GroupValue(summary...)),
+	}
+	if err != nil {
+		c.log.LogAttrs(ctx, slog.LevelError, "task failed", append(attrs, slog.String("error", err.Error()))...)
+		return
+	}
+	c.log.LogAttrs(ctx, slog.LevelInfo, "task finished", attrs...)
+}
+
+func (c *cleaner) purgeTrash(ctx context.Context) ([]slog.Attr, error) {
+	scrolls, jars, err := c.store.PurgeTrash(ctx, time.Now().Add(-c.cfg.TrashRetention))
+	return []slog.Attr{slog.Int64("scrolls", scrolls), slog.Int64("jars", jars)}, err
+}
+
+func (c *cleaner) purgeExpiredJars(ctx context.Context) ([]slog.Attr, error) {
+	jars, err := c.store.DeleteExpiredJars(ctx)
+	return []slog.Attr{slog.Int64("jars", jars)}, err
+}
+
+func (c *cleaner) purgeUnuploadedScrolls(ctx context.Context) ([]slog.Attr, error) {
+	scrolls, err := c.store.PurgeUnuploadedScrolls(ctx, pgtype.Timestamptz{Time: time.Now().Add(-c.cfg.UnuploadedRetention), Valid: true})
+	return []slog.Attr{slog.Int64("scrolls", scrolls)}, err
+}
+
+func (c *cleaner) purgeExpiredTokens(ctx context.Context) ([]slog.Attr, error) {
+	tokens, err := c.store.DeleteExpiredTokens(ctx)
+	return []slog.Attr{slog.Int64("tokens", tokens)}, err
+}
+
+// deleteRecordedObjects works through the outbox of objects whose scrolls
+// were deleted. Deletions that fail are retried on a later run.
+func (c *cleaner) deleteRecordedObjects(ctx context.Context) ([]slog.Attr, error) {
+	// The staged content of finished uploads is queued along with the rest.
+	uploads, err := c.store.PurgeScrollUploads(ctx, pgtype.Timestamptz{Time: time.Now().Add(-uploadRetention), Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, failures := 0, 0
+	summary := func() []slog.Attr {
+		return []slog.Attr{
+			slog.Int64("uploads", uploads),
+			slog.Int("objects", deleted),
+			slog.Int("failures", failures),
+		}
+	}
+	for {
+		rows, err := c.store.ClaimStorageDeletions(ctx, batchSize)
+		if err != nil {
+			return summary(), err
+		}
+
+		var objects []types.ObjectIdentifier
+		// Each key is traced back to the deletion that asked for it.
+		owners := make(map[string]int64)
+		failed := make(map[int64]error)
+		for _, row := range rows {
+			// A key ending in a slash stands for just the objects under it,
+			// the chunks of a scroll that was sealed.
+			prefix := row.Key
+			if !strings.HasSuffix(prefix, "/") {
+				owners[row.Key] = row.ID
+				objects = append(objects, types.ObjectIdentifier{Key: &row.Key})
+				if !row.Prefix {
+					continue
+				}
+				prefix += "/"
+			}
+			chunks, err := c.s3Bucket.ListObjects(ctx, prefix, "")
+			if err != nil {
+				failed[row.ID] = err
+				continue
+			}
+			for _, obj := range chunks {
+				owners[*obj.Key] = row.ID
+				objects = append(objects, types.ObjectIdentifier{Key: obj.Key})
+			}
+		}
+
+		for batch := range slices.Chunk(objects, batchSize) {
+			errKeys, err := c.s3Bucket.DeleteBatch(batch)
+			if err != nil {
+				for _, obj := range batch {
+					failed[owners[*obj.Key]] = err
+				}
+				continue
+			}
+			for _, key := range errKeys {
+				failed[owners[key]] = errObjectNotDeleted
+			}
+			deleted += len(batch) - len(errKeys)
+		}
+
+		var done []int64
+		for _, row := range rows {
+			err, ok := failed[row.ID]
+			if !ok {
+				done = append(done, row.ID)
+				continue
+			}
+			failures++
+			c.log.Error(err.Error(), "key", row.Key, "attempts", row.Attempts)
+			if err := c.store.SetStorageDeletionFailed(ctx, database.SetStorageDeletionFailedParams{
+				NextAttemptAt: pgtype.Timestamptz{Time: time.Now().Add(jobs.Backoff(row.Attempts, time.Minute, time.Hour)), Valid: true},
+				LastError:     pgtype.Text{String: err.Error(), Valid: true},
+				ID:            row.ID,
+			}); err != nil {
+				return summary(), err
+			}
+		}
+		if err := c.store.DeleteStorageDeletions(ctx, done); err != nil {
+			return summary(), err
+		}
+		if len(rows) < batchSize {
+			return summary(), nil
+		}
+	}
+}
+
+// reconcileReport is what a reconciliation did, written out as JSON so a run
+// can be checked, or a dry run previewed.
+type reconcileReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DryRun     bool      `json:"dry_run"`
+	Scanned    int       `json:"scanned"`
+	// Recent counts the orphans left alone for being younger than the
+	// minimum age.
+	Recent int `json:"recent"`
+	// Deleted lists the orphans deleted, or those that would have been in a
+	// dry run.
+	Deleted []string    `json:"deleted"`
+	Failed  []failedKey `json:"failed"`
+	// Capped is set when the run stopped at the maximum number of deletions.
+	Capped bool   `json:"capped"`
+	Error  string `json:"error,omitempty"`
+}
+
+type failedKey struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+func (r *reconcileReport) summary() []slog.Attr {
+	return []slog.Attr{
+		slog.Bool("dry_run", r.DryRun),
+		slog.Int("scanned", r.Scanned),
+		slog.Int("recent", r.Recent),
+		slog.Int("deleted", len(r.Deleted)),
+		slog.Int("failed", len(r.Failed)),
+		slog.Bool("capped", r.Capped),
+	}
+}
+
+// write saves the report to path, replacing the last run's report only once
+// the new one is complete.
+func (r *reconcileReport) write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// reconcileBucket lists the whole bucket and deletes the objects that belong
+// to no scroll, and chunks left over from sealed scrolls. The outbox covers
+// deleted and sealed scrolls, so this only catches what slipped past it, such
+// as objects of uploads that failed halfway.
+func (c *cleaner) reconcileBucket(ctx context.Context) ([]slog.Attr, error) {
+	report := &reconcileReport{
+		StartedAt: time.Now(),
+		DryRun:    c.cfg.DryRun,
+		Deleted:   []string{},
+		Failed:    []failedKey{},
+	}
+	err := c.reconcile(ctx, report)
+	report.FinishedAt = time.Now()
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if c.cfg.ReportPath != "" {
+		if err := report.write(c.cfg.ReportPath); err != nil {
+			c.log.Error(err.Error(), "report", c.cfg.ReportPath)
+		}
+	}
+	return report.summary(), err
+}
+
+// scrollKey is the key of the scroll an object belongs to, its jar and scroll
+// IDs. segment is set for the chunks of an appendable scroll, which follow
+// those IDs with their offset.
+func scrollKey(key string) (scroll string, segment bool, ok bool) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 2 {
+		return "", false, false
+	}
+	return parts[0] + "/" + parts[1], len(parts) == 3, true
+}
+
+func (c *cleaner) reconcile(ctx context.Context, report *reconcileReport) error {
+	var batch []types.Object
+	var scrollKeys []string
+	// Objects this new may belong to an upload whose scroll row isn't
+	// visible yet, or was just promoted.
+	cutoff := time.Now().Add(-c.cfg.MinObjectAge)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// A scroll's objects are matched by jar as well, so those left
+		// behind in the jar a scroll was moved out of are orphans too.
+		existing, err := c.store.GetExistingScrollKeys(ctx, scrollKeys)
+		if err != nil {
+			return err
+		}
+		// Chunks are only needed until their scroll is sealed.
+		unsealed := make(map[string]bool, len(existing))
+		for _, row := range existing {
+			unsealed[row.Key] = row.Unsealed
+		}
+		var toDelete []types.ObjectIdentifier
+		for _, obj := range batch {
+			key, segment, _ := scrollKey(*obj.Key)
+			if isUnsealed, ok := unsealed[key]; ok && (!segment || isUnsealed) {
+				continue
+			}
+			if obj.LastModified == nil || obj.LastModified.After(cutoff) {
+				report.Recent++
+				continue
+			}
+			if len(report.Deleted)+len(report.Failed)+len(toDelete) >= c.cfg.MaxDeletions {
+				report.Capped = true
+				break
+			}
+			toDelete = append(toDelete, types.ObjectIdentifier{Key: obj.Key})
+		}
+		batch, scrollKeys = batch[:0], scrollKeys[:0]
+		if len(toDelete) == 0 {
+			return nil
+		}
+		if c.cfg.DryRun {
+			for _, obj := range toDelete {
+				report.Deleted = append(report.Deleted, *obj.Key)
+			}
+			return nil
+		}
+
+		errKeys, err := c.s3Bucket.DeleteBatch(toDelete)
+		if err != nil {
+			for _, obj := range toDelete {
+				report.Failed = append(report.Failed, failedKey{*obj.Key, err.Error()})
+			}
+			return err
+		}
+		failed := make(map[string]bool, len(errKeys))
+		for _, key := range errKeys {
+			failed[key] = true
+			report.Failed = append(report.Failed, failedKey{key, errObjectNotDeleted.Error()})
+		}
+		for _, obj := range toDelete {
+			if !failed[*obj.Key] {
+				report.Deleted = append(report.Deleted, *obj.Key)
+			}
+		}
+		return nil
+	}
+
+	it := c.s3Bucket.NewAvilKeyIterator(ctx)
+	for !report.Capped {
+		obj, ok, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		report.Scanned++
+
+		// Staged uploads are cleaned up through the outbox.
+		key := *obj.Key
+		if strings.HasPrefix(key, database.StagingPrefix) {
+			continue
+		}
+		sk, _, ok := scrollKey(key)
+		if !ok {
+			continue
+		}
+		scrollKeys = append(scrollKeys, sk)
+		batch = append(batch, obj)
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -16,7 +16,9 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/jobs"
 	"github.com/kapilpokhrel/scrolljar/internal/mailer"
+	"github.com/kapilpokhrel/scrolljar/internal/render"
 )
 
 type Environment string
@@ -37,19 +39,33 @@ type Config struct {
 		IPRps     float64
 		IPBps     int
 	}
-	S3 database.S3CFG
+	S3                database.S3CFG
+	TrashRetention    time.Duration
+	RenderCacheBytes  int
+	AnonymousComments bool
 }
 
 type Application struct {
-	config    Config
-	dbPool    *pgxpool.Pool
-	logger    *slog.Logger
-	store     *database.Store
-	mailer    mailer.Mailer
-	wg        sync.WaitGroup
-	startTime time.Time
-	ipLimiter routeIPLimiter
-	s3Bucket  *database.S3Bucket
+	config      Config
+	dbPool      *pgxpool.Pool
+	logger      *slog.Logger
+	store       *database.Store
+	mailer      mailer.Mailer
+	jobs        *jobs.Queue
+	wg          sync.WaitGroup
+	startTime   time.Time
+	ipLimiter   routeIPLimiter
+	s3Bucket    *database.S3Bucket
+	renderCache *render.Cache
+	appends     broadcaster
+	jarEvents   jarEventHub
+	// webhookWake has the webhook worker look for deliveries before its
+	// next poll.
+	webhookWake   chan struct{}
+	webhookClient *http.Client
+	// closing is closed when the server starts shutting down, which ends
+	// the streams that would otherwise hold it up.
+	closing chan struct{}
 }
 
 func parseFlags() Config {
@@ -67,6 +83,9 @@ func parseFlags() Config {
 	fs.IntVar(&cfg.Rate.IPBps, "ip-burst", 15, "IP limit burst (per second)")
 
 	fs.StringVar(&cfg.S3.BucketName, "s3-bucket", os.Getenv("S3_BUCKET"), "s3 bucket")
+	fs.DurationVar(&cfg.TrashRetention, "trash-retention", database.DefaultTrashRetention, "How long deleted jars and scrolls can be restored")
+	fs.IntVar(&cfg.RenderCacheBytes, "render-cache-bytes", 64*1024*1024, "Memory for caching rendered scrolls")
+	fs.BoolVar(&cfg.AnonymousComments, "anonymous-comments", false, "Allow comments on scrolls without authentication")
 	fs.Parse(os.Args[1:])
 
 	return cfg
@@ -93,8 +112,16 @@ func NewApplication(logger *slog.Logger) (*Application, error) {
 		mailer:    mailer.New(cfg.SMTP),
 		startTime: time.Now(),
 		s3Bucket:  s3Bucket,
+
+		renderCache: render.NewCache(cfg.RenderCacheBytes),
+		closing:     make(chan struct{}),
+
+		webhookWake:   make(chan struct{}, 1),
+		webhookClient: newWebhookClient(cfg.Env),
 	}
 	app.ipLimiter = NewRouteIPLimiter(app.ipRateLimiter)
+	app.jobs = jobs.NewQueue(app.store, logger)
+	app.registerJobs()
 	return app, nil
 }
 
@@ -107,6 +134,20 @@ func (app *Application) Serve() error {
 		WriteTimeout:      3 * time.Minute,
 		ErrorLog:          slog.NewLogLogger(app.logger.Handler(), slog.LevelError),
 	}
+	server.RegisterOnShutdown(func() { close(app.closing) })
+	go app.listenJarEvents()
+	go app.deliverWebhooks()
+	jobsDone := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-app.closing
+			cancel()
+		}()
+		app.jobs.Run(ctx)
+		close(jobsDone)
+	}()
 
 	shutDownError := make(chan error)
 
@@ -129,6 +170,8 @@ func (app *Application) Serve() error {
 	if err := <-shutDownError; err != nil {
 		return err
 	}
+	// Jobs that are running get to finish before the database goes away.
+	<-jobsDone
 
 	app.logger.Info("server stopped")
 	app.dbPool.Close()
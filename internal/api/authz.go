@@ -0,0 +1,203 @@
+package api
+
+import (
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// jarRole is the caller's level of access to a jar. Roles are ordered so that
+// a higher role has every permission of the lower ones.
+type jarRole int
+
+const (
+	roleNone jarRole = iota
+	roleViewer
+	roleEditor
+	roleOwner
+)
+
+var memberRoles = map[string]jarRole{
+	string(spec.RoleViewer): roleViewer,
+	string(spec.RoleEditor): roleEditor,
+}
+
+// Failed password attempts beyond jarFreeAttempts lock the jar out of
+// password checks, doubling from jarBaseLockout up to jarMaxLockout.
+const (
+	jarFreeAttempts = 5
+	jarBaseLockout  = 30 * time.Second
+	jarMaxLockout   = 24 * time.Hour
+)
+
+func jarLockout(failures int32) time.Duration {
+	if failures < jarFreeAttempts {
+		return 0
+	}
+	shift := min(failures-jarFreeAttempts, 16)
+	return min(jarBaseLockout<<shift, jarMaxLockout)
+}
+
+// checkJarPassword verifies the password of a private jar, enforcing the
+// failed-attempt lockout so it can't be brute forced.
+func (app *Application) checkJarPassword(r *http.Request, jar database.Scrolljar, password string) error {
+	if jar.Access != int16(spec.AccessPrivate) {
+		return nil
+	}
+	if password == "" {
+		return errInvalidJarPass
+	}
+	// The attempt is counted before the password is checked, so guesses
+	// made in parallel can't all get in before the lockout.
+	allowed, lockedUntil, err := app.store.ClaimJarUnlockAttempt(r.Context(), jar.ID, jarLockout)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errJarLocked
+	}
+
+	if !verifyHashPassword(password, jar.PasswordHash.String) {
+		if !lockedUntil.IsZero() {
+			app.logger.Warn("jar locked after failed password attempts", "jar", jar.ID, "until", lockedUntil)
+		}
+		return errInvalidJarPass
+	}
+	return app.store.ResetJarUnlockFailures(r.Context(), jar.ID)
+}
+
+// jarRoleOf resolves the authenticated caller's role on the jar from its
+// ownership and the jar_member table.
+func (app *Application) jarRoleOf(r *http.Request, jar database.Scrolljar) (jarRole, error) {
+	user := app.contextGetUser(r)
+	if user == nil {
+		return roleNone, nil
+	}
+	if jar.UserID.Valid && jar.UserID.Int64 == user.ID {
+		return roleOwner, nil
+	}
+	role, err := app.store.GetJarMemberRole(r.Context(), database.GetJarMemberRoleParams{
+		JarID:  jar.ID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return roleNone, nil
+		}
+		return roleNone, err
+	}
+	return memberRoles[role], nil
+}
+
+// jarCredentials are the optional secrets a client can present to read a
+// private jar it has no role on.
+type jarCredentials struct {
+	password    string
+	shareToken  string
+	unlockToken string
+}
+
+// shareTokenOf returns the share token sent in the header, or else the one in
+// the share query parameter of a share link's URI.
+func (creds jarCredentials) shareTokenOf(r *http.Request) string {
+	if creds.shareToken != "" {
+		return creds.shareToken
+	}
+	return r.URL.Query().Get("share")
+}
+
+// authorizeJarRead returns errInvalidJarPass unless the jar is public, the
+// caller is its owner or a member, or the supplied credentials unlock it.
+// Each request made with a valid share token counts as one use of its link.
+func (app *Application) authorizeJarRead(r *http.Request, jar database.Scrolljar, creds jarCredentials) error {
+	if jar.Access != int16(spec.AccessPrivate) {
+		return nil
+	}
+	role, err := app.jarRoleOf(r, jar)
+	if err != nil {
+		return err
+	}
+	if role >= roleViewer {
+		return nil
+	}
+	if creds.unlockToken != "" {
+		if jarID, err := verifyJarUnlockToken(creds.unlockToken); err == nil && jarID == jar.ID {
+			return nil
+		}
+	}
+	if shareToken := creds.shareTokenOf(r); shareToken != "" {
+		tokenHash := sha256.Sum256([]byte(shareToken))
+		_, err := app.store.UseShareLink(r.Context(), database.UseShareLinkParams{
+			TokenHash: tokenHash[:],
+			JarID:     jar.ID,
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+	return app.checkJarPassword(r, jar, creds.password)
+}
+
+// reauthorizeJarRead checks that a caller authorizeJarRead let in can still
+// read the jar, for streams that outlive the request that opened them. It
+// doesn't use up share links or count password attempts, since the
+// credentials were already checked once.
+func (app *Application) reauthorizeJarRead(r *http.Request, jar database.Scrolljar, creds jarCredentials) error {
+	if jar.Access != int16(spec.AccessPrivate) {
+		return nil
+	}
+	role, err := app.jarRoleOf(r, jar)
+	if err != nil {
+		return err
+	}
+	if role >= roleViewer {
+		return nil
+	}
+	if creds.unlockToken != "" {
+		if jarID, err := verifyJarUnlockToken(creds.unlockToken); err == nil && jarID == jar.ID {
+			return nil
+		}
+	}
+	if shareToken := creds.shareTokenOf(r); shareToken != "" {
+		tokenHash := sha256.Sum256([]byte(shareToken))
+		_, err := app.store.GetActiveShareLinkID(r.Context(), database.GetActiveShareLinkIDParams{
+			TokenHash: tokenHash[:],
+			JarID:     jar.ID,
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+	if creds.password != "" && verifyHashPassword(creds.password, jar.PasswordHash.String) {
+		return nil
+	}
+	return errInvalidJarPass
+}
+
+// requireJarRole loads the jar and returns errInvalidCreds if the caller's
+// role on it is lower than min.
+func (app *Application) requireJarRole(r *http.Request, jarID string, min jarRole) (database.Scrolljar, error) {
+	jar, err := app.store.GetJar(r.Context(), jarID)
+	if err != nil {
+		return jar, dbErr(err)
+	}
+	role, err := app.jarRoleOf(r, jar)
+	if err != nil {
+		return jar, err
+	}
+	if role < min {
+		return jar, errInvalidCreds
+	}
+	return jar, nil
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+)
+
+// broadcaster wakes up everyone waiting on a key when something about it
+// changes. The zero value is ready to use.
+type broadcaster struct {
+	mu      sync.Mutex
+	waiting map[string]chan struct{}
+}
+
+// wait returns a channel that is closed on the next notify for key.
+func (b *broadcaster) wait(key string) <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.waiting == nil {
+		b.waiting = make(map[string]chan struct{})
+	}
+	ch, ok := b.waiting[key]
+	if !ok {
+		ch = make(chan struct{})
+		b.waiting[key] = ch
+	}
+	return ch
+}
+
+func (b *broadcaster) notify(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ch, ok := b.waiting[key]; ok {
+		close(ch)
+		delete(b.waiting, key)
+	}
+}
+
+// jarEventHub hands the jar events heard from the database to the streams
+// following each jar.
+type jarEventHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan database.JarEvent]struct{}
+}
+
+// jarEventBuffer is how many events a stream can fall behind by before it is
+// ended, leaving its client to reconnect and catch up.
+const jarEventBuffer = 64
+
+func (h *jarEventHub) subscribe(jarID string) chan database.JarEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[string]map[chan database.JarEvent]struct{})
+	}
+	if h.subs[jarID] == nil {
+		h.subs[jarID] = make(map[chan database.JarEvent]struct{})
+	}
+	ch := make(chan database.JarEvent, jarEventBuffer)
+	h.subs[jarID][ch] = struct{}{}
+	return ch
+}
+
+func (h *jarEventHub) unsubscribe(jarID string, ch chan database.JarEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs[jarID], ch)
+	if len(h.subs[jarID]) == 0 {
+		delete(h.subs, jarID)
+	}
+}
+
+func (h *jarEventHub) publish(event database.JarEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[event.JarID] {
+		select {
+		case ch <- event:
+		default:
+			close(ch)
+			delete(h.subs[event.JarID], ch)
+		}
+	}
+}
@@ -27,12 +27,21 @@ func (e *validationHTTPError) Error() string { return "validation error" }
 var (
 	errNotFound         = &httpError{http.StatusNotFound, "resources not found"}
 	errInvalidCreds     = &httpError{http.StatusUnauthorized, "invalid credentials"}
+	errAuthRequired     = &httpError{http.StatusUnauthorized, "you must be authenticated to access this resource"}
 	errInvalidJarPass   = &httpError{http.StatusUnauthorized, "invalid jar password"}
 	errInactiveAccount  = &httpError{http.StatusForbidden, "your user account must be activated to access this resource"}
+	errForkPrivate      = &httpError{http.StatusForbidden, "only public jars can be forked"}
 	errEntityTooLarge   = &httpError{http.StatusRequestEntityTooLarge, "entity too large"}
 	errAlreadyUploaded  = &httpError{http.StatusConflict, "already uploaded"}
+	errUploadInProgress = &httpError{http.StatusConflict, "upload token is already being used by another upload"}
+	errNotAppendable    = &httpError{http.StatusConflict, "scroll is not appendable"}
+	errAppendOnly       = &httpError{http.StatusConflict, "appendable scrolls take their content through appends"}
+	errScrollSealed     = &httpError{http.StatusConflict, "scroll is sealed"}
+	errUnknownEventID   = &httpError{http.StatusBadRequest, "Last-Event-ID is not the ID of an event from this stream"}
 	errAlreadyActivated = &httpError{http.StatusServiceUnavailable, "account already activated"}
 	errEditConflict     = &httpError{http.StatusConflict, "edit conflict; please try again"}
+	errSlugTaken        = &httpError{http.StatusConflict, "slug is already taken"}
+	errJarLocked        = &httpError{http.StatusTooManyRequests, "too many failed password attempts; jar is temporarily locked"}
 )
 
 func errBadRequest(err error) *httpError {
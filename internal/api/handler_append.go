@@ -0,0 +1,397 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// tailKeepAlive is how often a tail with nothing new sends a comment, so that
+// proxies don't time it out. Each time, the scroll is checked again for chunks
+// whose notification was missed while the database listener reconnected.
+const tailKeepAlive = 15 * time.Second
+
+// segmentPrefix is where the chunks of an appendable scroll are kept until it
+// is sealed. Each chunk is an object of its own, named by the offset it starts
+// at so that the chunks list in order.
+func segmentPrefix(scroll database.Scroll) string {
+	return filepath.Join(scroll.JarID, scroll.ID) + "/"
+}
+
+func segmentKey(scroll database.Scroll, offset int64) string {
+	return fmt.Sprintf("%s%020d", segmentPrefix(scroll), offset)
+}
+
+// errSegmentsGone means an appendable scroll's chunks no longer cover its
+// content, which happens once the scroll is sealed.
+var errSegmentsGone = errors.New("scroll segments are gone")
+
+// scrollSegments lists the keys of the chunks holding an unsealed appendable
+// scroll's content from offset on, up to the size the scroll was loaded with.
+func (app *Application) scrollSegments(ctx context.Context, scroll database.Scroll, offset int64) ([]string, error) {
+	var startAfter string
+	if offset > 0 {
+		startAfter = segmentKey(scroll, offset-1)
+	}
+	objects, err := app.s3Bucket.ListObjects(ctx, segmentPrefix(scroll), startAfter)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	// Chunks past the scroll's size belong to appends that haven't finished.
+	for _, obj := range objects {
+		if offset >= scroll.AppendedBytes {
+			break
+		}
+		if aws.ToString(obj.Key) != segmentKey(scroll, offset) {
+			return nil, errSegmentsGone
+		}
+		keys = append(keys, *obj.Key)
+		offset += aws.ToInt64(obj.Size)
+	}
+	if offset < scroll.AppendedBytes {
+		return nil, errSegmentsGone
+	}
+	return keys, nil
+}
+
+// segmentReader reads the chunks of an appendable scroll one after another.
+type segmentReader struct {
+	ctx    context.Context
+	bucket *database.S3Bucket
+	keys   []string
+	cur    io.ReadCloser
+}
+
+func (sr *segmentReader) Read(p []byte) (int, error) {
+	for {
+		if sr.cur == nil {
+			if len(sr.keys) == 0 {
+				return 0, io.EOF
+			}
+			body, err := sr.bucket.GetObject(sr.ctx, sr.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			sr.cur, sr.keys = body, sr.keys[1:]
+		}
+		n, err := sr.cur.Read(p)
+		if err == io.EOF {
+			sr.cur.Close()
+			sr.cur = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (sr *segmentReader) Close() error {
+	if sr.cur != nil {
+		return sr.cur.Close()
+	}
+	return nil
+}
+
+// openScrollContent opens a scroll's content for reading, joining up the
+// chunks of an appendable scroll that hasn't been sealed. The caller must
+// close it.
+func (app *Application) openScrollContent(ctx context.Context, scroll database.Scroll) (io.ReadCloser, error) {
+	if scroll.Appendable && !scroll.Uploaded {
+		keys, err := app.scrollSegments(ctx, scroll, 0)
+		if err == nil {
+			return &segmentReader{ctx: ctx, bucket: app.s3Bucket, keys: keys}, nil
+		}
+		// Otherwise the scroll has been sealed since it was loaded.
+		if !errors.Is(err, errSegmentsGone) {
+			return nil, err
+		}
+	}
+	return app.s3Bucket.GetObject(ctx, filepath.Join(scroll.JarID, scroll.ID))
+}
+
+// appendableScroll loads the scroll an upload token was issued for, checking
+// that it is appendable and not yet sealed. It also returns the user the
+// token was issued to, or -1.
+func (app *Application) appendableScroll(ctx context.Context, id spec.ScrollID, uploadToken string) (database.Scroll, int64, error) {
+	claims, err := verifyScrollUploadToken(uploadToken)
+	if err != nil || claims.scrollID != id {
+		return database.Scroll{}, -1, errNotFound
+	}
+	scroll, err := app.store.GetScroll(ctx, claims.scrollID)
+	if err != nil {
+		return scroll, -1, dbErr(err)
+	}
+	if !scroll.Appendable {
+		return scroll, -1, errNotAppendable
+	}
+	if scroll.Uploaded {
+		return scroll, -1, errScrollSealed
+	}
+	return scroll, claims.userID, nil
+}
+
+func (app *Application) AppendScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.AppendScrollParams) {
+	if err := app.appendScroll(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) appendScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.AppendScrollParams) error {
+	scroll, userID, err := app.appendableScroll(r.Context(), id, params.XUploadToken)
+	if err != nil {
+		return err
+	}
+	maxSize := maxScrollSize(userID)
+	if scroll.AppendedBytes >= maxSize {
+		return errEntityTooLarge
+	}
+
+	// Chunks are small enough to hold on to, which lets them be checked
+	// before any room is made for them.
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize-scroll.AppendedBytes)
+	chunk, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return errEntityTooLarge
+		}
+		return errBadRequest(err)
+	}
+	if len(chunk) == 0 {
+		return errBadRequest(errors.New("empty chunk"))
+	}
+	if !utf8.Valid(chunk) {
+		return errBadRequest(errors.New("invalid text content"))
+	}
+
+	size := int64(len(chunk))
+	start, err := app.store.AppendScrollChunk(r.Context(), scroll.ID, size, maxSize, func(start int64) error {
+		_, err := app.s3Bucket.StreamingUpload(bytes.NewReader(chunk), segmentKey(scroll, start))
+		return err
+	})
+	if err != nil {
+		return dbErrWithConflict(err)
+	}
+	return app.writeJSON(w, http.StatusOK, spec.ScrollAppend{
+		Offset: start,
+		Size:   start + size,
+	}, nil)
+}
+
+func (app *Application) SealScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.SealScrollParams) {
+	if err := app.sealScroll(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) sealScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.SealScrollParams) error {
+	scroll, _, err := app.appendableScroll(r.Context(), id, params.XUploadToken)
+	if err != nil {
+		return err
+	}
+
+	// The chunks are joined into the object an uploaded scroll would have,
+	// while appends wait for the seal.
+	var guess *database.SetScrollFormatGuessParams
+	updatedAt, err := app.store.SealScroll(r.Context(), scroll.ID, func(size int64) (string, *database.SetScrollFormatGuessParams, error) {
+		scroll.AppendedBytes = size
+		keys, err := app.scrollSegments(r.Context(), scroll, 0)
+		if err != nil {
+			return "", nil, err
+		}
+		content := &cappedBuffer{max: searchIndexLimit}
+		body := io.TeeReader(&segmentReader{ctx: r.Context(), bucket: app.s3Bucket, keys: keys}, content)
+		if _, err := app.s3Bucket.StreamingUpload(body, filepath.Join(scroll.JarID, scroll.ID)); err != nil {
+			return "", nil, err
+		}
+		text := content.Text()
+		guess = guessScrollFormat(scroll, text)
+		return text, guess, nil
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errScrollSealed
+		}
+		return err
+	}
+
+	scroll.UpdatedAt = updatedAt
+	scroll.Uploaded = true
+	scroll.Revision++
+	if guess != nil {
+		scroll.Format = guess.Format
+		scroll.FormatConfidence = guess.FormatConfidence
+	}
+	fetchURL, err := app.s3Bucket.GetScrollFetchURL(scroll.JarID, scroll.ID)
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, spec.ScrollFetch{
+		Scroll:   dbScrollToSpec(scroll),
+		FetchURL: fetchURL,
+	}, nil)
+}
+
+func (app *Application) TailScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.TailScrollParams) {
+	if err := app.tailScroll(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) tailScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.TailScrollParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	offset, _ := params.LastOffset()
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
+	if err != nil {
+		return err
+	}
+	if !scroll.Appendable {
+		return errNotAppendable
+	}
+	if err := app.checkTailOffset(r.Context(), scroll, offset); err != nil {
+		return err
+	}
+
+	// The server's write timeout would cut the stream short.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// From here on the status has been sent, so errors only end the stream.
+	ctx := r.Context()
+	keepAlive := time.NewTicker(tailKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		// Waiting starts before the scroll is loaded, so that nothing
+		// appended in between goes unnoticed.
+		appended := app.appends.wait(scroll.ID)
+		scroll, err = app.store.GetScroll(ctx, scroll.ID)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) && ctx.Err() == nil {
+				app.logger.Error(err.Error(), "scroll", id)
+			}
+			return nil
+		}
+		if scroll.Uploaded {
+			if err := app.tailSealed(ctx, w, scroll, offset); err != nil {
+				app.logger.Error(err.Error(), "scroll", scroll.ID)
+				return nil
+			}
+			rc.Flush()
+			return nil
+		}
+
+		keys, err := app.scrollSegments(ctx, scroll, offset)
+		if err != nil && !errors.Is(err, errSegmentsGone) {
+			app.logger.Error(err.Error(), "scroll", scroll.ID)
+			return nil
+		}
+		// With the chunks gone, the scroll is sealed by the next round.
+		for _, key := range keys {
+			chunk, err := app.readObject(ctx, key)
+			if err != nil {
+				app.logger.Error(err.Error(), "key", key)
+				return nil
+			}
+			offset += int64(len(chunk))
+			if err := writeEvent(w, "chunk", strconv.FormatInt(offset, 10), string(chunk)); err != nil {
+				return nil
+			}
+		}
+		rc.Flush()
+
+		select {
+		case <-appended:
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			rc.Flush()
+		case <-ctx.Done():
+			return nil
+		case <-app.closing:
+			return nil
+		}
+	}
+}
+
+// checkTailOffset checks that a tail resumes from the end of a chunk the
+// client could have been sent. Any other offset would never line up with the
+// chunks, leaving the stream with nothing to send.
+func (app *Application) checkTailOffset(ctx context.Context, scroll database.Scroll, offset int64) error {
+	if offset > scroll.AppendedBytes {
+		return errUnknownEventID
+	}
+	if offset == 0 || scroll.Uploaded {
+		return nil
+	}
+	_, err := app.scrollSegments(ctx, scroll, offset)
+	if !errors.Is(err, errSegmentsGone) {
+		return err
+	}
+	// A sealed scroll's content can be resumed from any offset.
+	scroll, err = app.store.GetScroll(ctx, scroll.ID)
+	if err != nil {
+		return dbErr(err)
+	}
+	if !scroll.Uploaded {
+		return errUnknownEventID
+	}
+	return nil
+}
+
+// tailSealed ends the tail of a sealed scroll with whatever the client hasn't
+// seen yet, which is in the scroll's object by now.
+func (app *Application) tailSealed(ctx context.Context, w io.Writer, scroll database.Scroll, offset int64) error {
+	if offset < scroll.AppendedBytes {
+		body, err := app.s3Bucket.GetObjectFrom(ctx, filepath.Join(scroll.JarID, scroll.ID), offset)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		rest, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		if err := writeEvent(w, "chunk", strconv.FormatInt(scroll.AppendedBytes, 10), string(rest)); err != nil {
+			return err
+		}
+	}
+	return writeEvent(w, "sealed", "", strconv.FormatInt(scroll.AppendedBytes, 10))
+}
+
+func (app *Application) readObject(ctx context.Context, key string) ([]byte, error) {
+	body, err := app.s3Bucket.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
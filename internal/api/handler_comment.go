@@ -0,0 +1,194 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// dbCommentToSpec converts a comment on scroll. A comment made on an earlier
+// revision of the scroll is outdated, since its lines may have moved.
+func dbCommentToSpec(comment database.ScrollComment, username string, scroll database.Scroll) spec.ScrollComment {
+	return spec.ScrollComment{
+		ID:        comment.ID,
+		ScrollID:  comment.ScrollID,
+		UserID:    comment.UserID.Int64,
+		Username:  username,
+		Revision:  comment.Revision,
+		Outdated:  comment.Revision < scroll.Revision,
+		LineStart: comment.LineStart,
+		LineEnd:   comment.LineEnd,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func (app *Application) GetScrollComments(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollCommentsParams) {
+	if err := app.getScrollComments(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getScrollComments(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollCommentsParams) error {
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
+	if err != nil {
+		return err
+	}
+	comments, err := app.store.GetScrollComments(r.Context(), scroll.ID)
+	if err != nil {
+		return err
+	}
+	out := make(spec.ScrollCommentCollection, len(comments))
+	for i, c := range comments {
+		out[i] = dbCommentToSpec(database.ScrollComment{
+			ID:        c.ID,
+			ScrollID:  c.ScrollID,
+			UserID:    c.UserID,
+			Revision:  c.Revision,
+			LineStart: c.LineStart,
+			LineEnd:   c.LineEnd,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}, c.Username.String, scroll)
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) CreateScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.CreateScrollCommentParams) {
+	if err := app.createScrollComment(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) createScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.CreateScrollCommentParams) error {
+	input := spec.CreateScrollCommentInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	user := app.contextGetUser(r)
+	if user == nil && !app.config.AnonymousComments {
+		return errAuthRequired
+	}
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	if input.Revision == 0 {
+		input.Revision = scroll.Revision
+	}
+	if input.Revision > scroll.Revision {
+		v.AddError(spec.FieldError{Field: []string{"revision"}, Msg: "the scroll has no such revision"})
+		return errValidation(spec.ValidationError(*v))
+	}
+	if input.LineEnd == 0 {
+		input.LineEnd = input.LineStart
+	}
+	arg := database.InsertScrollCommentParams{
+		ScrollID:  scroll.ID,
+		Revision:  input.Revision,
+		LineStart: input.LineStart,
+		LineEnd:   input.LineEnd,
+		Body:      input.Body,
+	}
+	var username string
+	if user != nil {
+		arg.UserID = pgtype.Int8{Int64: user.ID, Valid: true}
+		username = user.Username
+	}
+	comment, err := app.store.InsertScrollComment(r.Context(), arg)
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, dbCommentToSpec(comment, username, scroll), nil)
+}
+
+// scrollComment loads a comment along with the live scroll it is on.
+func (app *Application) scrollComment(r *http.Request, id spec.ScrollID, commentID spec.CommentID) (database.Scroll, database.ScrollComment, error) {
+	scroll, err := app.store.GetScroll(r.Context(), id)
+	if err != nil {
+		return scroll, database.ScrollComment{}, dbErr(err)
+	}
+	comment, err := app.store.GetScrollComment(r.Context(), database.GetScrollCommentParams{
+		ID:       commentID,
+		ScrollID: scroll.ID,
+	})
+	if err != nil {
+		return scroll, comment, dbErr(err)
+	}
+	return scroll, comment, nil
+}
+
+func (app *Application) PatchScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, commentID spec.CommentID) {
+	if err := app.patchScrollComment(w, r, id, commentID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) patchScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, commentID spec.CommentID) error {
+	input := spec.ScrollCommentPatchInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	scroll, comment, err := app.scrollComment(r, id, commentID)
+	if err != nil {
+		return err
+	}
+	user := app.contextGetUser(r)
+	if !comment.UserID.Valid || comment.UserID.Int64 != user.ID {
+		return errInvalidCreds
+	}
+	comment, err = app.store.UpdateScrollCommentBody(r.Context(), database.UpdateScrollCommentBodyParams{
+		Body: input.Body,
+		ID:   comment.ID,
+	})
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, dbCommentToSpec(comment, user.Username, scroll), nil)
+}
+
+func (app *Application) DeleteScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, commentID spec.CommentID) {
+	if err := app.deleteScrollComment(w, r, id, commentID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) deleteScrollComment(w http.ResponseWriter, r *http.Request, id spec.ScrollID, commentID spec.CommentID) error {
+	scroll, comment, err := app.scrollComment(r, id, commentID)
+	if err != nil {
+		return err
+	}
+	// Authors can delete their own comments, and owners any comment on
+	// their jar.
+	user := app.contextGetUser(r)
+	if !comment.UserID.Valid || comment.UserID.Int64 != user.ID {
+		if _, err := app.requireJarRole(r, scroll.JarID, roleOwner); err != nil {
+			return err
+		}
+	}
+	if _, err := app.store.DeleteScrollComment(r.Context(), comment.ID); err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "comment deleted"}, nil)
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// listenJarEvents passes the jar events from the database on to the jar
+// streams, and wakes the tails of the scrolls they are about, until the
+// server shuts down. It reconnects whenever the connection is lost; events
+// sent while it is disconnected are missed.
+func (app *Application) listenJarEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-app.closing
+		cancel()
+	}()
+
+	backoff := time.Second
+	for {
+		err := app.store.ListenJarEvents(ctx, func(event database.JarEvent) {
+			backoff = time.Second
+			if event.ScrollID != "" {
+				app.appends.notify(event.ScrollID)
+			}
+			if event.Type == database.ScrollAppendedEvent {
+				return
+			}
+			app.jarEvents.publish(event)
+			// The change that sent the event queued its webhook deliveries.
+			app.wakeWebhooks()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		app.logger.Error(err.Error(), "retry", backoff.String())
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(2*backoff, time.Minute)
+	}
+}
+
+// jarEventsReauth is how often a jar stream checks that its client can still
+// read the jar, so that removing a member or revoking a share link ends the
+// streams they opened.
+const jarEventsReauth = time.Minute
+
+// jarExpiry fires when the jar expires, or never for a jar without expiry.
+func jarExpiry(jar database.Scrolljar) <-chan time.Time {
+	if !jar.ExpiresAt.Valid {
+		return nil
+	}
+	return time.After(time.Until(jar.ExpiresAt.Time))
+}
+
+func writeJarEvent(w io.Writer, event spec.JarEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return writeEvent(w, string(event.Type), "", string(data))
+}
+
+func (app *Application) GetJarEvents(w http.ResponseWriter, r *http.Request, id spec.JarID, params spec.GetJarEventsParams) {
+	if err := app.getJarEvents(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getJarEvents(w http.ResponseWriter, r *http.Request, id spec.JarID, params spec.GetJarEventsParams) error {
+	creds := jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	}
+	jar, err := app.store.GetJar(r.Context(), id)
+	if err != nil {
+		return dbErr(err)
+	}
+	if err := app.authorizeJarRead(r, jar, creds); err != nil {
+		return err
+	}
+	events := app.jarEvents.subscribe(jar.ID)
+	defer app.jarEvents.unsubscribe(jar.ID, events)
+
+	// The server's write timeout would cut the stream short.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	// From here on the status has been sent, so errors only end the stream.
+	ctx := r.Context()
+	expires := jarExpiry(jar)
+	keepAlive := time.NewTicker(tailKeepAlive)
+	defer keepAlive.Stop()
+	reauth := time.NewTicker(jarEventsReauth)
+	defer reauth.Stop()
+	// stillReadable reloads the jar, whose expiry or access may have
+	// changed, and checks the client's access again.
+	stillReadable := func() bool {
+		jar, err = app.store.GetJar(ctx, jar.ID)
+		if err != nil || app.reauthorizeJarRead(r, jar, creds) != nil {
+			return false
+		}
+		expires = jarExpiry(jar)
+		return true
+	}
+	for {
+		select {
+		case event, ok := <-events:
+			// A stream that fell behind has been dropped.
+			if !ok {
+				return nil
+			}
+			if err := writeJarEvent(w, spec.JarEvent{
+				Type:     spec.JarEventType(event.Type),
+				JarID:    event.JarID,
+				ScrollID: event.ScrollID,
+			}); err != nil {
+				return nil
+			}
+			rc.Flush()
+			switch spec.JarEventType(event.Type) {
+			case spec.JarEventJarDeleted, spec.JarEventJarExpired:
+				return nil
+			case spec.JarEventJarUpdated:
+				if !stillReadable() {
+					return nil
+				}
+			}
+		case <-reauth.C:
+			if !stillReadable() {
+				return nil
+			}
+		case <-expires:
+			writeJarEvent(w, spec.JarEvent{Type: spec.JarEventJarExpired, JarID: jar.ID})
+			rc.Flush()
+			return nil
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			rc.Flush()
+		case <-ctx.Done():
+			return nil
+		case <-app.closing:
+			return nil
+		}
+	}
+}
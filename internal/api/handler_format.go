@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/kapilpokhrel/scrolljar/internal/formats"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+func (app *Application) GetFormats(w http.ResponseWriter, r *http.Request) {
+	all := formats.All()
+	out := spec.FormatList{Formats: make([]spec.Format, len(all))}
+	for i, f := range all {
+		out.Formats[i] = spec.Format{
+			Name:       f.Name,
+			Aliases:    f.Aliases,
+			Extensions: f.Extensions,
+			MimeType:   f.MIMEType,
+		}
+		if out.Formats[i].Aliases == nil {
+			out.Formats[i].Aliases = []string{}
+		}
+		if out.Formats[i].Extensions == nil {
+			out.Formats[i].Extensions = []string{}
+		}
+	}
+	app.writeJSON(w, http.StatusOK, out, nil)
+}
@@ -1,11 +1,17 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/render"
 	"github.com/kapilpokhrel/scrolljar/internal/spec"
 )
 
@@ -33,8 +39,10 @@ func (app *Application) createJar(w http.ResponseWriter, r *http.Request) error
 	scrollArgs := make([]database.InsertScrollParams, len(input.Scrolls))
 	for i, s := range input.Scrolls {
 		scrollArgs[i] = database.InsertScrollParams{
-			Title:  pgtype.Text{String: s.Title, Valid: s.Title != ""},
-			Format: pgtype.Text{String: s.Format, Valid: s.Format != ""},
+			Title:      pgtype.Text{String: s.Title, Valid: s.Title != ""},
+			Format:     scrollFormat(s.Format, s.Filename),
+			Filename:   pgtype.Text{String: s.Filename, Valid: s.Filename != ""},
+			Appendable: s.Appendable,
 		}
 	}
 
@@ -45,7 +53,7 @@ func (app *Application) createJar(w http.ResponseWriter, r *http.Request) error
 
 	createdScrolls := make([]spec.CreateScrollOutput, len(scrolls))
 	for i, scroll := range scrolls {
-		uploadToken, err := createScrollUploadToken(scroll.ID, scroll.JarID, user)
+		uploadToken, err := createScrollUploadToken(scroll, user)
 		if err != nil {
 			return err
 		}
@@ -72,29 +80,137 @@ func (app *Application) getJar(w http.ResponseWriter, r *http.Request, id spec.J
 	if err != nil {
 		return dbErr(err)
 	}
-	if err := checkJarPassword(jar, params.XPastePassword); err != nil {
-		return errInvalidCreds
+	if err := app.authorizeJarRead(r, jar, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	}); err != nil {
+		return err
 	}
-	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+	forks, err := app.store.GetJarForkCount(r.Context(), pgtype.Text{String: jar.ID, Valid: true})
+	if err != nil {
+		return err
+	}
+	out := dbJarToSpec(jar)
+	out.ForkCount = &forks
+	out.Description, err = app.jarDescription(r.Context(), jar.ID)
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+// jarDescription renders a jar's README, if it has one. A README that can't be
+// fetched only leaves the description out. The rendering is cached by the
+// README's revision, so storage is only read again once it is uploaded again.
+func (app *Application) jarDescription(ctx context.Context, jarID string) (string, error) {
+	readme, err := app.store.GetJarReadme(ctx, jarID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("readme/%s/%d", readme.ID, readme.Revision)
+	if html, ok := app.renderCache.Get(key); ok {
+		return string(html), nil
+	}
+	// Inline styles keep a stylesheet out of the description.
+	html, err := app.renderScrollContent(ctx, readme, render.Options{Markdown: true, Inline: true})
+	if err != nil {
+		app.logger.Error(err.Error(), "scroll", readme.ID)
+		return "", nil
+	}
+	app.renderCache.Add(key, html)
+	return string(html), nil
 }
 
-func (app *Application) GetJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID) {
-	if err := app.getJarScrolls(w, r, id); err != nil {
+func (app *Application) ForkJar(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.forkJar(w, r, id); err != nil {
 		app.handleError(w, r, err)
 	}
 }
 
-func (app *Application) getJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
-	if _, err := app.store.GetJar(r.Context(), id); err != nil {
+func (app *Application) forkJar(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	src, err := app.store.GetJar(r.Context(), id)
+	if err != nil {
 		return dbErr(err)
 	}
-	scrolls, err := app.store.GetScrollsByJar(r.Context(), id)
+	// A fork is public, so forking a private jar would publish it.
+	if src.Access != int16(spec.AccessPublic) {
+		return errForkPrivate
+	}
+	srcScrolls, err := app.store.GetScrollsByJar(r.Context(), src.ID)
+	if err != nil {
+		return err
+	}
+
+	jar, scrolls, err := app.store.ForkJar(r.Context(), src, app.contextGetUser(r).ID, srcScrolls)
 	if err != nil {
 		return err
 	}
-	out := make([]spec.Scroll, len(scrolls))
-	for i, s := range scrolls {
-		out[i] = dbScrollToSpec(s)
+	ids := make([]string, len(scrolls))
+	for i, scroll := range scrolls {
+		srcKey := filepath.Join(srcScrolls[i].JarID, srcScrolls[i].ID)
+		if err := app.s3Bucket.CopyObject(srcKey, filepath.Join(jar.ID, scroll.ID)); err != nil {
+			// Don't leave a partial fork around; the cleaner removes whatever
+			// was copied once the trashed jar is purged.
+			if _, err := app.store.TrashJar(r.Context(), jar.ID); err != nil {
+				app.logger.Error(err.Error(), "jar", jar.ID)
+			}
+			return err
+		}
+		ids[i] = scroll.ID
+	}
+	if err := app.store.SetScrollsUploaded(r.Context(), ids); err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+}
+
+func (app *Application) GetJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID, params spec.GetJarScrollsParams) {
+	if err := app.getJarScrolls(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID, params spec.GetJarScrollsParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.store.GetJar(r.Context(), id)
+	if err != nil {
+		return dbErr(err)
+	}
+	if err := app.authorizeJarRead(r, jar, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	}); err != nil {
+		return err
+	}
+	// Scrolls are listed in the jar's own order unless asked otherwise.
+	if params.Sort == "" {
+		params.Sort = spec.GetJarScrollsParamsSortPosition
+	}
+	if params.Sort == spec.GetJarScrollsParamsSortPosition && params.Order == "" {
+		params.Order = spec.SortAsc
+	}
+	scrolls, err := app.store.ListJarScrolls(r.Context(), database.ListJarScrollsParams{
+		PageParams: pageParams(params.Limit, params.Cursor, string(params.Sort), params.Order),
+		JarID:      jar.ID,
+	})
+	if err != nil {
+		return pageErr(err)
+	}
+	out := spec.ScrollPage{
+		Items:      make([]spec.Scroll, len(scrolls.Items)),
+		NextCursor: scrolls.NextCursor,
+		Total:      scrolls.Total,
+	}
+	for i, s := range scrolls.Items {
+		out.Items[i] = dbScrollToSpec(s)
 	}
 	return app.writeJSON(w, http.StatusOK, out, nil)
 }
@@ -106,13 +222,105 @@ func (app *Application) DeleteJar(w http.ResponseWriter, r *http.Request, id spe
 }
 
 func (app *Application) deleteJar(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
-	if err := app.requireJarCreator(r, id); err != nil {
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+	if _, err := app.store.TrashJar(r.Context(), jar.ID); err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "scrolljar moved to trash"}, nil)
+}
+
+func (app *Application) SetJarSlug(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.setJarSlug(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) setJarSlug(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.SetJarSlugInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	if !app.contextGetUser(r).Activated {
+		return errInactiveAccount
+	}
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
 		return err
 	}
-	if err := app.store.DeleteJar(r.Context(), id); err != nil {
+	jar, err = app.store.SetJarSlug(r.Context(), jar.ID, input.Slug)
+	if err != nil {
+		if errors.Is(err, database.ErrDuplicateSlug) {
+			return errSlugTaken
+		}
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+}
+
+func (app *Application) ReorderJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.reorderJarScrolls(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) reorderJarScrolls(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.ReorderJarScrollsInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.requireJarRole(r, id, roleEditor)
+	if err != nil {
+		return err
+	}
+	updatedAt, err := app.store.ReorderJarScrolls(r.Context(), jar.ID, input.ScrollIds, input.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, database.ErrScrollOrder) {
+			v.AddError(spec.FieldError{Field: []string{"scroll_ids"}, Msg: "scroll_ids must list every scroll of the jar exactly once"})
+			return errValidation(spec.ValidationError(*v))
+		}
+		return dbErrWithConflict(err)
+	}
+	jar.UpdatedAt = updatedAt
+	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+}
+
+func (app *Application) UnlockJar(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.unlockJar(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) unlockJar(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.UnlockJarInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	jar, err := app.store.GetJar(r.Context(), id)
+	if err != nil {
+		return dbErr(err)
+	}
+	if jar.Access != int16(spec.AccessPrivate) {
+		return errBadRequest(errors.New("only private jars can be unlocked"))
+	}
+	if err := app.checkJarPassword(r, jar, input.Password); err != nil {
+		return err
+	}
+	token, expiry, err := createJarUnlockToken(jar.ID)
+	if err != nil {
 		return err
 	}
-	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "scrolljar deleted successfully"}, nil)
+	return app.writeJSON(w, http.StatusOK, spec.Token{Token: token, Expiry: expiry}, nil)
 }
 
 func buildInsertJarParams(input spec.CreateJarInput, user *database.UserAccount) database.InsertJarParams {
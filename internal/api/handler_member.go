@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+func (app *Application) GetJarMembers(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.getJarMembers(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getJarMembers(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	jar, err := app.requireJarRole(r, id, roleViewer)
+	if err != nil {
+		return err
+	}
+	members, err := app.store.GetJarMembers(r.Context(), jar.ID)
+	if err != nil {
+		return err
+	}
+	out := make([]spec.JarMember, len(members))
+	for i, m := range members {
+		out[i] = spec.JarMember{
+			UserID:    m.UserID,
+			Username:  m.Username,
+			Role:      spec.JarRole(m.Role),
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) AddJarMember(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.addJarMember(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) addJarMember(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.AddJarMemberInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+
+	member, err := app.findInvitee(r, string(input.Email), input.Username, v)
+	if err != nil {
+		return err
+	}
+	if jar.UserID.Int64 == member.ID {
+		v.AddError(spec.FieldError{Field: []string{"user"}, Msg: "the owner can't be added as a member"})
+		return errValidation(spec.ValidationError(*v))
+	}
+
+	row, err := app.store.UpsertJarMember(r.Context(), database.UpsertJarMemberParams{
+		JarID:  jar.ID,
+		UserID: member.ID,
+		Role:   string(input.Role),
+	})
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, spec.JarMember{
+		UserID:    row.UserID,
+		Username:  member.Username,
+		Role:      spec.JarRole(row.Role),
+		CreatedAt: row.CreatedAt,
+	}, nil)
+}
+
+// findInvitee looks up the activated user identified by email or, when that is
+// empty, username. Usernames aren't unique, so an ambiguous username is rejected.
+func (app *Application) findInvitee(r *http.Request, email, username string, v *spec.Validator) (database.UserAccount, error) {
+	if email != "" {
+		user, err := app.store.GetUserByEmail(r.Context(), email)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+		if err != nil || !user.Activated {
+			v.AddError(spec.FieldError{Field: []string{"email"}, Msg: "no activated user with this email"})
+			return user, errValidation(spec.ValidationError(*v))
+		}
+		return user, nil
+	}
+
+	users, err := app.store.GetActivatedUsersByUsername(r.Context(), username)
+	if err != nil {
+		return database.UserAccount{}, err
+	}
+	switch len(users) {
+	case 0:
+		v.AddError(spec.FieldError{Field: []string{"username"}, Msg: "no activated user with this username"})
+	case 1:
+		return users[0], nil
+	default:
+		v.AddError(spec.FieldError{Field: []string{"username"}, Msg: "username is shared by several users, invite by email instead"})
+	}
+	return database.UserAccount{}, errValidation(spec.ValidationError(*v))
+}
+
+func (app *Application) RemoveJarMember(w http.ResponseWriter, r *http.Request, id spec.JarID, userID spec.UserID) {
+	if err := app.removeJarMember(w, r, id, userID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) removeJarMember(w http.ResponseWriter, r *http.Request, id spec.JarID, userID spec.UserID) error {
+	// Members may leave a jar on their own; removing anyone else takes the owner.
+	minRole := roleOwner
+	if app.contextGetUser(r).ID == userID {
+		minRole = roleViewer
+	}
+	jar, err := app.requireJarRole(r, id, minRole)
+	if err != nil {
+		return err
+	}
+	n, err := app.store.DeleteJarMember(r.Context(), database.DeleteJarMemberParams{
+		JarID:  jar.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNotFound
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "member removed successfully"}, nil)
+}
@@ -1,15 +1,26 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/formats"
+	"github.com/kapilpokhrel/scrolljar/internal/render"
 	"github.com/kapilpokhrel/scrolljar/internal/spec"
 )
 
+// searchIndexLimit is how much of a scroll's content is indexed for search.
+const searchIndexLimit = 256 * 1024
+
 func (app *Application) CreateScroll(w http.ResponseWriter, r *http.Request, id spec.JarID) {
 	if err := app.createScroll(w, r, id); err != nil {
 		app.handleError(w, r, err)
@@ -21,19 +32,26 @@ func (app *Application) createScroll(w http.ResponseWriter, r *http.Request, id
 	if err := app.readJSON(w, r, &input); err != nil {
 		return errBadRequest(err)
 	}
-	if err := app.requireJarCreator(r, id); err != nil {
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.requireJarRole(r, id, roleEditor)
+	if err != nil {
 		return err
 	}
 	user := app.contextGetUser(r)
 	scroll, err := app.store.InsertScroll(r.Context(), database.InsertScrollParams{
-		JarID:  id,
-		Title:  pgtype.Text{String: input.Title, Valid: input.Title != ""},
-		Format: pgtype.Text{String: input.Format, Valid: input.Format != ""},
+		JarID:      jar.ID,
+		Title:      pgtype.Text{String: input.Title, Valid: input.Title != ""},
+		Format:     scrollFormat(input.Format, input.Filename),
+		Filename:   pgtype.Text{String: input.Filename, Valid: input.Filename != ""},
+		Appendable: input.Appendable,
 	})
 	if err != nil {
 		return err
 	}
-	uploadToken, err := createScrollUploadToken(scroll.ID, scroll.JarID, user)
+	uploadToken, err := createScrollUploadToken(scroll, user)
 	if err != nil {
 		return err
 	}
@@ -43,35 +61,186 @@ func (app *Application) createScroll(w http.ResponseWriter, r *http.Request, id
 	}, nil)
 }
 
+// scrollFormat resolves the format to store for a scroll: the canonical name
+// of the given format, or else the one implied by its filename, if any.
+func scrollFormat(format, filename string) pgtype.Text {
+	if f, ok := formats.Lookup(format); ok {
+		return pgtype.Text{String: f.Name, Valid: true}
+	}
+	if f, ok := formats.FromFilename(filename); ok {
+		return pgtype.Text{String: f.Name, Valid: true}
+	}
+	return pgtype.Text{}
+}
+
 func (app *Application) GetScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollParams) {
 	if err := app.getScroll(w, r, id, params); err != nil {
 		app.handleError(w, r, err)
 	}
 }
 
-func (app *Application) getScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollParams) error {
+// readableScroll loads an uploaded scroll, or an appendable one that is still
+// being written, checking that the credentials allow reading its jar.
+func (app *Application) readableScroll(r *http.Request, id spec.ScrollID, creds jarCredentials) (database.Scroll, error) {
 	scroll, err := app.store.GetScroll(r.Context(), id)
 	if err != nil {
-		return dbErr(err)
+		return scroll, dbErr(err)
 	}
-	if !scroll.Uploaded {
-		return errNotFound
+	if !scroll.Uploaded && !scroll.Appendable {
+		return scroll, errNotFound
 	}
 	jar, err := app.store.GetJar(r.Context(), scroll.JarID)
 	if err != nil {
-		return dbErr(err)
+		return scroll, dbErr(err)
 	}
-	if err := checkJarPassword(jar, params.XPastePassword); err != nil {
-		return errInvalidJarPass
+	return scroll, app.authorizeJarRead(r, jar, creds)
+}
+
+func (app *Application) getScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollParams) error {
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
+	if err != nil {
+		return err
 	}
-	fetchURL, err := app.s3Bucket.GetScrollFetchURL(scroll.JarID, scroll.ID)
+	out := spec.ScrollFetch{Scroll: dbScrollToSpec(scroll)}
+	// An appendable scroll has no object to fetch until it is sealed.
+	if scroll.Uploaded {
+		out.FetchURL, err = app.s3Bucket.GetScrollFetchURL(scroll.JarID, scroll.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) RenderScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.RenderScrollParams) {
+	if err := app.renderScroll(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) renderScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.RenderScrollParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
 	if err != nil {
 		return err
 	}
-	return app.writeJSON(w, http.StatusOK, spec.ScrollFetch{
-		Scroll:   dbScrollToSpec(scroll),
-		FetchURL: fetchURL,
-	}, nil)
+
+	opts := render.Options{
+		Theme:    params.Theme,
+		Inline:   params.Style == spec.RenderStyleInline,
+		Markdown: scroll.Format.String == formats.Markdown && !params.Source,
+	}
+	if params.Lines != "" {
+		lines, _ := spec.ParseLineRange(params.Lines)
+		opts.Lines = [2]int{lines.Start, lines.End}
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="canonical"`, scrollLinesURI(scroll.ID, lines)))
+	}
+	html, err := app.renderScrollContent(r.Context(), scroll, opts)
+	if err != nil {
+		return err
+	}
+	return app.writeHTML(w, http.StatusOK, html)
+}
+
+func (app *Application) GetScrollRaw(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollRawParams) {
+	if err := app.getScrollRaw(w, r, id, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getScrollRaw(w http.ResponseWriter, r *http.Request, id spec.ScrollID, params spec.GetScrollRawParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	scroll, err := app.readableScroll(r, id, jarCredentials{
+		password:    params.XPastePassword,
+		shareToken:  params.XShareToken,
+		unlockToken: params.XJarToken,
+	})
+	if err != nil {
+		return err
+	}
+	var lines spec.LineRange
+	if params.Lines != "" {
+		lines, _ = spec.ParseLineRange(params.Lines)
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="canonical"`, scrollLinesURI(scroll.ID, lines)))
+	}
+
+	body, err := app.openScrollContent(r.Context(), scroll)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// The line count is only known once the content has been streamed, so
+	// it is sent as a trailer, and up front too when an earlier request
+	// counted this version of the scroll.
+	countKey := lineCountKey(scroll)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if count, ok := app.renderCache.Get(countKey); ok {
+		w.Header().Set("X-Total-Lines", string(count))
+	}
+	w.Header().Set("Trailer", "X-Total-Lines")
+	w.WriteHeader(http.StatusOK)
+	total, err := copyLines(w, body, lines)
+	if err != nil {
+		// The status has been sent, so all that is left is to cut the
+		// response short.
+		app.logger.Error(err.Error(), "scroll", scroll.ID)
+		return nil
+	}
+	count := strconv.Itoa(total)
+	w.Header().Set("X-Total-Lines", count)
+	app.renderCache.Add(countKey, []byte(count))
+	return nil
+}
+
+// lineCountKey is where a scroll's line count is cached. Counts are kept by
+// revision, and for an appendable scroll by how much has been appended, so a
+// count is never sent for content it wasn't taken from.
+func lineCountKey(scroll database.Scroll) string {
+	return fmt.Sprintf("lines/%s/%d/%d", scroll.ID, scroll.Revision, scroll.AppendedBytes)
+}
+
+// renderScrollContent fetches a scroll's content and renders it to HTML,
+// reusing an earlier rendering of the same content when there is one.
+func (app *Application) renderScrollContent(ctx context.Context, scroll database.Scroll, opts render.Options) ([]byte, error) {
+	body, err := app.openScrollContent(ctx, scroll)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Theme == "" {
+		opts.Theme = render.DefaultTheme
+	}
+	key := render.CacheKey(string(content), scroll.Format.String, opts)
+	if html, ok := app.renderCache.Get(key); ok {
+		return html, nil
+	}
+	var buf bytes.Buffer
+	if err := render.Render(&buf, string(content), scroll.Format.String, opts); err != nil {
+		return nil, err
+	}
+	app.renderCache.Add(key, buf.Bytes())
+	return buf.Bytes(), nil
 }
 
 func (app *Application) PatchScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) {
@@ -85,6 +254,10 @@ func (app *Application) patchScroll(w http.ResponseWriter, r *http.Request, id s
 	if err := app.readJSON(w, r, &input); err != nil {
 		return errBadRequest(err)
 	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
 	scroll, err := app.store.GetScroll(r.Context(), id)
 	if err != nil {
 		return dbErr(err)
@@ -92,27 +265,49 @@ func (app *Application) patchScroll(w http.ResponseWriter, r *http.Request, id s
 	if !scroll.Uploaded {
 		return errNotFound
 	}
-	if err := app.requireJarCreator(r, scroll.JarID); err != nil {
+	if _, err := app.requireJarRole(r, scroll.JarID, roleEditor); err != nil {
 		return err
 	}
 	if input.Title != nil {
 		scroll.Title = pgtype.Text{String: *input.Title, Valid: true}
 	}
+	if input.Filename != nil {
+		scroll.Filename = pgtype.Text{String: *input.Filename, Valid: *input.Filename != ""}
+	}
+	if input.Readme != nil {
+		scroll.IsReadme = *input.Readme
+	}
+	// A new filename only changes the format when it implies one. Either way
+	// the format is no longer a guess.
 	if input.Format != nil {
-		scroll.Format = pgtype.Text{String: *input.Format, Valid: true}
+		scroll.Format = scrollFormat(*input.Format, scroll.Filename.String)
+		scroll.FormatConfidence = pgtype.Float4{}
+	} else if input.Filename != nil {
+		if f := scrollFormat("", *input.Filename); f.Valid {
+			scroll.Format = f
+			scroll.FormatConfidence = pgtype.Float4{}
+		}
+	}
+	// READMEs are rendered as a jar's description, which only Markdown is.
+	if scroll.IsReadme && scroll.Format.String != formats.Markdown {
+		v.AddError(spec.FieldError{Field: []string{"readme"}, Msg: "only markdown scrolls can be a jar's README"})
+		return errValidation(spec.ValidationError(*v))
 	}
 	updatedAt, err := app.store.UpdateScroll(r.Context(), database.UpdateScrollParams{
-		Title:     scroll.Title,
-		Format:    scroll.Format,
-		ID:        scroll.ID,
-		UpdatedAt: scroll.UpdatedAt,
+		Title:            scroll.Title,
+		Format:           scroll.Format,
+		FormatConfidence: scroll.FormatConfidence,
+		Filename:         scroll.Filename,
+		IsReadme:         scroll.IsReadme,
+		ID:               scroll.ID,
+		UpdatedAt:        scroll.UpdatedAt,
 	})
 	if err != nil {
 		return dbErrWithConflict(err)
 	}
 	scroll.UpdatedAt = updatedAt
 	user := app.contextGetUser(r)
-	uploadToken, err := createScrollUploadToken(scroll.ID, scroll.JarID, user)
+	uploadToken, err := createScrollUploadToken(scroll, user)
 	if err != nil {
 		return err
 	}
@@ -133,13 +328,13 @@ func (app *Application) deleteScroll(w http.ResponseWriter, r *http.Request, id
 	if err != nil {
 		return dbErr(err)
 	}
-	if err := app.requireJarCreator(r, scroll.JarID); err != nil {
+	if _, err := app.requireJarRole(r, scroll.JarID, roleOwner); err != nil {
 		return err
 	}
-	if err := app.store.DeleteScroll(r.Context(), id); err != nil {
+	if _, err := app.store.TrashScroll(r.Context(), id); err != nil {
 		return err
 	}
-	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "scroll deleted successfully"}, nil)
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "scroll moved to trash"}, nil)
 }
 
 func (app *Application) UploadScroll(w http.ResponseWriter, r *http.Request, params spec.UploadScrollParams) {
@@ -149,26 +344,54 @@ func (app *Application) UploadScroll(w http.ResponseWriter, r *http.Request, par
 }
 
 func (app *Application) uploadScroll(w http.ResponseWriter, r *http.Request, params spec.UploadScrollParams) error {
-	scrollID, jarID, userID, err := verifyScrollUploadToken(params.XUploadToken)
+	claims, err := verifyScrollUploadToken(params.XUploadToken)
 	if err != nil {
 		return errNotFound
 	}
-	scroll, err := app.store.GetScroll(r.Context(), scrollID)
+	scroll, err := app.store.GetScroll(r.Context(), claims.scrollID)
 	if err != nil {
 		return dbErr(err)
 	}
 	if scroll.Uploaded {
 		return errAlreadyUploaded
 	}
+	if scroll.Appendable {
+		return errAppendOnly
+	}
 
-	var maxSize int64 = 1 * 1024 * 1024
-	if userID >= 0 {
-		maxSize = 5 * 1024 * 1024
+	// Content is staged under a key of its own and only promoted to the
+	// scroll's key once the scroll is claimed, so an upload that loses the
+	// claim leaves the scroll's content alone. Recording the token keeps two
+	// uploads from using it at once.
+	stagingKey := database.StagingPrefix + rand.Text()
+	n, err := app.store.InsertScrollUpload(r.Context(), database.InsertScrollUploadParams{
+		Jti:        claims.jti,
+		ScrollID:   scroll.ID,
+		StagingKey: stagingKey,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errUploadInProgress
 	}
-	r.Body = http.MaxBytesReader(w, r.Body, maxSize+1)
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		// The token can be tried again, and the staged content is deleted
+		// by the cleaner.
+		if err := app.store.ReleaseScrollUpload(context.WithoutCancel(r.Context()), claims.jti); err != nil {
+			app.logger.Error(err.Error(), "scroll", scroll.ID)
+		}
+	}()
 
-	key := filepath.Join(jarID, scrollID)
-	_, err = app.s3Bucket.StreamingUpload(utf8ValidationReader{r: r.Body}, key)
+	r.Body = http.MaxBytesReader(w, r.Body, maxScrollSize(claims.userID)+1)
+
+	content := &cappedBuffer{max: searchIndexLimit}
+	body := io.TeeReader(utf8ValidationReader{r: r.Body}, content)
+	_, err = app.s3Bucket.StreamingUpload(body, stagingKey)
 	if err != nil {
 		if errors.Is(err, utf8Err) {
 			return errBadRequest(errors.New("invalid text content"))
@@ -179,17 +402,33 @@ func (app *Application) uploadScroll(w http.ResponseWriter, r *http.Request, par
 		return err
 	}
 
-	updatedAt, err := app.store.SetScrollUploaded(r.Context(), database.SetScrollUploadedParams{
+	key := filepath.Join(scroll.JarID, scroll.ID)
+	text := content.Text()
+	guess := guessScrollFormat(scroll, text)
+	updatedAt, err := app.store.CompleteScrollUpload(r.Context(), database.SetScrollUploadedParams{
 		ID:        scroll.ID,
 		UpdatedAt: scroll.UpdatedAt,
+	}, text, guess, func() error {
+		return app.s3Bucket.CopyObject(stagingKey, key)
 	})
 	if err != nil {
 		return dbErrWithConflict(err)
 	}
+	committed = true
+	// Whatever is left behind is deleted once the upload is forgotten.
+	if err := app.s3Bucket.DeleteObject(stagingKey); err != nil {
+		app.logger.Error(err.Error(), "key", stagingKey)
+	}
+
 	scroll.UpdatedAt = updatedAt
 	scroll.Uploaded = true
+	scroll.Revision++
+	if guess != nil {
+		scroll.Format = guess.Format
+		scroll.FormatConfidence = guess.FormatConfidence
+	}
 
-	fetchURL, err := app.s3Bucket.GetScrollFetchURL(jarID, scrollID)
+	fetchURL, err := app.s3Bucket.GetScrollFetchURL(scroll.JarID, scroll.ID)
 	if err != nil {
 		return err
 	}
@@ -198,3 +437,133 @@ func (app *Application) uploadScroll(w http.ResponseWriter, r *http.Request, par
 		FetchURL: fetchURL,
 	}, nil)
 }
+
+// maxScrollSize is the most content a scroll can hold, which is more when its
+// upload token was issued to a user.
+func maxScrollSize(userID int64) int64 {
+	if userID >= 0 {
+		return 5 * 1024 * 1024
+	}
+	return 1 * 1024 * 1024
+}
+
+// guessScrollFormat guesses the format of a scroll created without one from its
+// content, returning nil when the scroll has a format or nothing fits.
+func guessScrollFormat(scroll database.Scroll, text string) *database.SetScrollFormatGuessParams {
+	if scroll.Format.Valid {
+		return nil
+	}
+	name, confidence := formats.Detect(scroll.Filename.String, text)
+	if name == "" {
+		return nil
+	}
+	return &database.SetScrollFormatGuessParams{
+		Format:           pgtype.Text{String: name, Valid: true},
+		FormatConfidence: pgtype.Float4{Float32: confidence, Valid: true},
+		ID:               scroll.ID,
+	}
+}
+
+// scrollAndDestination loads an uploaded scroll and the jar it is to be moved
+// or copied into, checking that the caller owns both jars.
+func (app *Application) scrollAndDestination(w http.ResponseWriter, r *http.Request, id spec.ScrollID) (database.Scroll, database.Scrolljar, error) {
+	input := spec.ScrollDestinationInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return database.Scroll{}, database.Scrolljar{}, errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return database.Scroll{}, database.Scrolljar{}, errValidation(spec.ValidationError(*v))
+	}
+	scroll, err := app.store.GetScroll(r.Context(), id)
+	if err != nil {
+		return scroll, database.Scrolljar{}, dbErr(err)
+	}
+	if !scroll.Uploaded {
+		return scroll, database.Scrolljar{}, errNotFound
+	}
+	if _, err := app.requireJarRole(r, scroll.JarID, roleOwner); err != nil {
+		return scroll, database.Scrolljar{}, err
+	}
+	dst, err := app.requireJarRole(r, input.JarID, roleOwner)
+	if err != nil {
+		return scroll, dst, err
+	}
+	if dst.ID == scroll.JarID {
+		v.AddError(spec.FieldError{Field: []string{"jarid"}, Msg: "scroll is already in this jar"})
+		return scroll, dst, errValidation(spec.ValidationError(*v))
+	}
+	return scroll, dst, nil
+}
+
+func (app *Application) MoveScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) {
+	if err := app.moveScroll(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) moveScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) error {
+	scroll, dst, err := app.scrollAndDestination(w, r, id)
+	if err != nil {
+		return err
+	}
+
+	// Storage keys are prefixed by the jar, so the object is copied to its new
+	// key before the row moves, and the old one is queued for deletion along
+	// with the move.
+	srcKey := filepath.Join(scroll.JarID, scroll.ID)
+	dstKey := filepath.Join(dst.ID, scroll.ID)
+	if err := app.s3Bucket.CopyObject(srcKey, dstKey); err != nil {
+		return err
+	}
+	updatedAt, err := app.store.MoveScroll(r.Context(), database.MoveScrollParams{
+		JarID:     dst.ID,
+		ID:        scroll.ID,
+		UpdatedAt: scroll.UpdatedAt,
+	}, database.InsertMovedScrollDeletionParams{
+		NewKey: dstKey,
+		OldKey: srcKey,
+	})
+	if err != nil {
+		if err := app.s3Bucket.DeleteObject(dstKey); err != nil {
+			app.logger.Error(err.Error(), "key", dstKey)
+		}
+		return dbErrWithConflict(err)
+	}
+
+	scroll.JarID = dst.ID
+	scroll.UpdatedAt = updatedAt
+	return app.writeJSON(w, http.StatusOK, dbScrollToSpec(scroll), nil)
+}
+
+func (app *Application) CopyScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) {
+	if err := app.copyScroll(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) copyScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) error {
+	src, dst, err := app.scrollAndDestination(w, r, id)
+	if err != nil {
+		return err
+	}
+	scroll, err := app.store.InsertScrollCopy(r.Context(), src, dst.ID)
+	if err != nil {
+		return err
+	}
+	// The copy stays hidden until it is marked uploaded, so a failed storage
+	// copy only leaves an unlisted row behind.
+	if err := app.s3Bucket.CopyObject(filepath.Join(src.JarID, src.ID), filepath.Join(scroll.JarID, scroll.ID)); err != nil {
+		return err
+	}
+	updatedAt, err := app.store.SetScrollUploaded(r.Context(), database.SetScrollUploadedParams{
+		ID:        scroll.ID,
+		UpdatedAt: scroll.UpdatedAt,
+	})
+	if err != nil {
+		return dbErrWithConflict(err)
+	}
+	scroll.Uploaded = true
+	scroll.UpdatedAt = updatedAt
+	return app.writeJSON(w, http.StatusOK, dbScrollToSpec(scroll), nil)
+}
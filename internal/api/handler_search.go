@@ -0,0 +1,60 @@
+package api
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+const defaultSearchLimit = 20
+
+// snippetMarkers replaces the control characters used as ts_headline
+// delimiters with <mark> tags once the rest of the snippet has been escaped.
+var snippetMarkers = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func (app *Application) Search(w http.ResponseWriter, r *http.Request, params spec.SearchParams) {
+	if err := app.search(w, r, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) search(w http.ResponseWriter, r *http.Request, params spec.SearchParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultSearchLimit
+	}
+
+	var userID *int64
+	if user := app.contextGetUser(r); user != nil {
+		userID = &user.ID
+	}
+	rows, err := app.store.SearchScrolls(r.Context(), params.Q, userID, int32(params.Limit), int32(params.Offset))
+	if err != nil {
+		return err
+	}
+
+	out := spec.SearchResults{Results: make([]spec.SearchHit, len(rows))}
+	for i, row := range rows {
+		out.Total = row.Total
+		out.Results[i] = spec.SearchHit{
+			Scroll: dbScrollToSpec(database.Scroll{
+				ID:        row.ID,
+				JarID:     row.JarID,
+				Title:     row.Title,
+				Format:    row.Format,
+				Uploaded:  row.Uploaded,
+				CreatedAt: row.CreatedAt,
+				UpdatedAt: row.UpdatedAt,
+			}),
+			Rank:    row.Rank,
+			Snippet: snippetMarkers.Replace(html.EscapeString(row.Snippet)),
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+const defaultShareLinkExpiry = 24 * time.Hour
+
+func shareLinkURI(jarID, token string) string {
+	return jarURI(jarID) + "?share=" + token
+}
+
+func dbShareLinkToSpec(link database.JarShareLink) spec.ShareLink {
+	return spec.ShareLink{
+		ID:        link.ID,
+		JarID:     link.JarID,
+		ExpiresAt: link.ExpiresAt,
+		MaxUses:   link.MaxUses.Int32,
+		Uses:      link.Uses,
+		Revoked:   link.RevokedAt.Valid,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+func (app *Application) GetShareLinks(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.getShareLinks(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getShareLinks(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+	links, err := app.store.GetShareLinksByJar(r.Context(), jar.ID)
+	if err != nil {
+		return err
+	}
+	out := make([]spec.ShareLink, len(links))
+	for i, l := range links {
+		out[i] = dbShareLinkToSpec(l)
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) CreateShareLink(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.createShareLink(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) createShareLink(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.CreateShareLinkInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+	if jar.Access != int16(spec.AccessPrivate) {
+		return errBadRequest(errors.New("public jars don't need share links"))
+	}
+
+	expiry := defaultShareLinkExpiry
+	if input.Expiry.Duration != nil {
+		expiry = *input.Expiry.Duration
+	}
+	link, token, err := app.store.CreateShareLink(r.Context(), jar.ID, time.Now().Add(expiry), input.MaxUses)
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, spec.CreateShareLinkOutput{
+		Link:  dbShareLinkToSpec(link),
+		Token: token,
+		URI:   shareLinkURI(jar.ID, token),
+	}, nil)
+}
+
+func (app *Application) RevokeShareLink(w http.ResponseWriter, r *http.Request, id spec.JarID, linkID int64) {
+	if err := app.revokeShareLink(w, r, id, linkID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) revokeShareLink(w http.ResponseWriter, r *http.Request, id spec.JarID, linkID int64) error {
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+	n, err := app.store.RevokeShareLink(r.Context(), database.RevokeShareLinkParams{
+		ID:    linkID,
+		JarID: jar.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNotFound
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "share link revoked successfully"}, nil)
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// jarTransferExpiry is how long the target of a transfer has to accept it.
+const jarTransferExpiry = 7 * 24 * time.Hour
+
+func dbJarTransferToSpec(t database.GetJarTransfersByUserRow) spec.JarTransfer {
+	return spec.JarTransfer{
+		ID:           t.ID,
+		JarID:        t.JarID,
+		JarName:      t.JarName.String,
+		FromUserID:   t.FromUserID,
+		FromUsername: t.FromUsername,
+		ToUserID:     t.ToUserID,
+		ToUsername:   t.ToUsername,
+		ExpiresAt:    t.ExpiresAt,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+func (app *Application) CreateJarTransfer(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.createJarTransfer(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) createJarTransfer(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	input := spec.CreateJarTransferInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	jar, err := app.requireJarRole(r, id, roleOwner)
+	if err != nil {
+		return err
+	}
+
+	owner := app.contextGetUser(r)
+	target, err := app.findInvitee(r, string(input.Email), input.Username, v)
+	if err != nil {
+		return err
+	}
+	if target.ID == owner.ID {
+		v.AddError(spec.FieldError{Field: []string{"user"}, Msg: "you already own this jar"})
+		return errValidation(spec.ValidationError(*v))
+	}
+
+	transfer, err := app.store.UpsertJarTransferWithJob(r.Context(), database.UpsertJarTransferParams{
+		JarID:      jar.ID,
+		FromUserID: owner.ID,
+		ToUserID:   target.ID,
+		ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(jarTransferExpiry), Valid: true},
+	}, func(transfer database.JarTransfer) (database.InsertJobParams, error) {
+		return jarTransferMailJob.Job(jarTransferMail{
+			Recipient:  target.Email,
+			From:       owner.Username,
+			JarID:      jar.ID,
+			JarName:    jar.Name.String,
+			TransferID: transfer.ID,
+			ExpiresAt:  transfer.ExpiresAt.Time,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	app.jobs.Wake()
+
+	return app.writeJSON(w, http.StatusOK, dbJarTransferToSpec(database.GetJarTransfersByUserRow{
+		ID:           transfer.ID,
+		JarID:        transfer.JarID,
+		FromUserID:   transfer.FromUserID,
+		ToUserID:     transfer.ToUserID,
+		ExpiresAt:    transfer.ExpiresAt,
+		CreatedAt:    transfer.CreatedAt,
+		JarName:      jar.Name,
+		FromUsername: owner.Username,
+		ToUsername:   target.Username,
+	}), nil)
+}
+
+func (app *Application) GetUserTransfers(w http.ResponseWriter, r *http.Request) {
+	if err := app.getUserTransfers(w, r); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getUserTransfers(w http.ResponseWriter, r *http.Request) error {
+	user := app.contextGetUser(r)
+	transfers, err := app.store.GetJarTransfersByUser(r.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	out := spec.JarTransferList{
+		Incoming: []spec.JarTransfer{},
+		Outgoing: []spec.JarTransfer{},
+	}
+	for _, t := range transfers {
+		if t.ToUserID == user.ID {
+			out.Incoming = append(out.Incoming, dbJarTransferToSpec(t))
+		} else {
+			out.Outgoing = append(out.Outgoing, dbJarTransferToSpec(t))
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) AcceptJarTransfer(w http.ResponseWriter, r *http.Request, transferID spec.TransferID) {
+	if err := app.acceptJarTransfer(w, r, transferID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) acceptJarTransfer(w http.ResponseWriter, r *http.Request, transferID spec.TransferID) error {
+	jarID, err := app.store.AcceptJarTransfer(r.Context(), transferID, app.contextGetUser(r).ID)
+	if err != nil {
+		return dbErr(err)
+	}
+	jar, err := app.store.GetJar(r.Context(), jarID)
+	if err != nil {
+		return dbErr(err)
+	}
+	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+}
+
+func (app *Application) DeleteJarTransfer(w http.ResponseWriter, r *http.Request, transferID spec.TransferID) {
+	if err := app.deleteJarTransfer(w, r, transferID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) deleteJarTransfer(w http.ResponseWriter, r *http.Request, transferID spec.TransferID) error {
+	n, err := app.store.DeleteJarTransfer(r.Context(), database.DeleteJarTransferParams{
+		ID:     transferID,
+		UserID: app.contextGetUser(r).ID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNotFound
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "transfer cancelled successfully"}, nil)
+}
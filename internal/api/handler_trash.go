@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+// trashCutoff is the oldest deletion time that can still be restored.
+func (app *Application) trashCutoff() time.Time {
+	return time.Now().Add(-app.config.TrashRetention)
+}
+
+func (app *Application) GetUserTrash(w http.ResponseWriter, r *http.Request) {
+	if err := app.getUserTrash(w, r); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getUserTrash(w http.ResponseWriter, r *http.Request) error {
+	user := app.contextGetUser(r)
+	jars, scrolls, err := app.store.GetUserTrash(r.Context(), user.ID, app.trashCutoff())
+	if err != nil {
+		return err
+	}
+
+	out := spec.Trash{
+		Jars:    make([]spec.TrashedJar, len(jars)),
+		Scrolls: make([]spec.TrashedScroll, len(scrolls)),
+	}
+	for i, jar := range jars {
+		out.Jars[i] = spec.TrashedJar{
+			Jar:       dbJarToSpec(jar),
+			DeletedAt: jar.DeletedAt,
+			PurgeAt:   jar.DeletedAt.Time.Add(app.config.TrashRetention),
+		}
+	}
+	for i, scroll := range scrolls {
+		out.Scrolls[i] = spec.TrashedScroll{
+			Scroll:    dbScrollToSpec(scroll),
+			DeletedAt: scroll.DeletedAt,
+			PurgeAt:   scroll.DeletedAt.Time.Add(app.config.TrashRetention),
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) RestoreJar(w http.ResponseWriter, r *http.Request, id spec.JarID) {
+	if err := app.restoreJar(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) restoreJar(w http.ResponseWriter, r *http.Request, id spec.JarID) error {
+	// Only the owner sees the trash, so anyone else gets a 404.
+	jar, err := app.store.RestoreJar(r.Context(), id, app.contextGetUser(r).ID, app.trashCutoff())
+	if err != nil {
+		return dbErr(err)
+	}
+	return app.writeJSON(w, http.StatusOK, dbJarToSpec(jar), nil)
+}
+
+func (app *Application) RestoreScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) {
+	if err := app.restoreScroll(w, r, id); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) restoreScroll(w http.ResponseWriter, r *http.Request, id spec.ScrollID) error {
+	if err := app.store.RestoreScroll(r.Context(), id, app.contextGetUser(r).ID, app.trashCutoff()); err != nil {
+		return dbErr(err)
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "scroll restored successfully"}, nil)
+}
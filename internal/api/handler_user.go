@@ -32,10 +32,12 @@ func (app *Application) createUser(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	user, tokenText, err := app.store.CreateUserWithActivationToken(r.Context(), database.InsertUserParams{
+	user, err := app.store.CreateUserWithJob(r.Context(), database.InsertUserParams{
 		Username:     input.Username,
 		Email:        string(input.Email),
 		PasswordHash: pwHash,
+	}, func(user database.UserAccount) (database.InsertJobParams, error) {
+		return activationMailJob.Job(activationMail{UserID: user.ID})
 	})
 	if err != nil {
 		if errors.Is(err, database.ErrDuplicateUser) {
@@ -44,22 +46,7 @@ func (app *Application) createUser(w http.ResponseWriter, r *http.Request) error
 		}
 		return err
 	}
-
-	userData := struct {
-		ID    int64
-		Token string
-		Email string
-	}{ID: user.ID, Token: tokenText, Email: user.Email}
-
-	app.backgroundTask(func() {
-		for i := 1; i <= 3; i++ {
-			if err := app.mailer.Send(user.Email, "user_verify.html", userData); err == nil {
-				return
-			} else {
-				app.logger.Error(err.Error())
-			}
-		}
-	}, "Registration Mail")
+	app.jobs.Wake()
 
 	return app.writeJSON(w, http.StatusOK, dbUserToSpec(user), nil)
 }
@@ -108,21 +95,40 @@ func (app *Application) GetUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (app *Application) GetUserJars(w http.ResponseWriter, r *http.Request) {
-	if err := app.getUserJars(w, r); err != nil {
+func (app *Application) GetUserJars(w http.ResponseWriter, r *http.Request, params spec.GetUserJarsParams) {
+	if err := app.getUserJars(w, r, params); err != nil {
 		app.handleError(w, r, err)
 	}
 }
 
-func (app *Application) getUserJars(w http.ResponseWriter, r *http.Request) error {
+func (app *Application) getUserJars(w http.ResponseWriter, r *http.Request, params spec.GetUserJarsParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+
 	user := app.contextGetUser(r)
-	jars, err := app.store.GetJarsByUser(r.Context(), user.ID)
+	arg := database.ListUserJarsParams{
+		PageParams:     pageParams(params.Limit, params.Cursor, string(params.Sort), params.Order),
+		UserID:         user.ID,
+		HasPassword:    params.HasPassword,
+		ExpiringBefore: params.ExpiringBefore,
+	}
+	if params.Access != nil {
+		access := int16(*params.Access)
+		arg.Access = &access
+	}
+	jars, err := app.store.ListUserJars(r.Context(), arg)
 	if err != nil {
-		return err
+		return pageErr(err)
+	}
+	out := spec.JarPage{
+		Items:      make([]spec.Jar, len(jars.Items)),
+		NextCursor: jars.NextCursor,
+		Total:      jars.Total,
 	}
-	out := make([]spec.Jar, len(jars))
-	for i, j := range jars {
-		out[i] = dbJarToSpec(j)
+	for i, j := range jars.Items {
+		out.Items[i] = dbJarToSpec(j)
 	}
 	return app.writeJSON(w, http.StatusOK, out, nil)
 }
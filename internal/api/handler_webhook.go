@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+func dbWebhookToSpec(webhook database.Webhook) spec.Webhook {
+	events := make([]spec.JarEventType, len(webhook.Events))
+	for i, e := range webhook.Events {
+		events[i] = spec.JarEventType(e)
+	}
+	return spec.Webhook{
+		ID:        webhook.ID,
+		JarID:     webhook.JarID.String,
+		URL:       webhook.Url,
+		Events:    events,
+		Active:    webhook.Active,
+		Failures:  webhook.Failures,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func dbWebhookDeliveryToSpec(delivery database.WebhookDelivery) (spec.WebhookDelivery, error) {
+	out := spec.WebhookDelivery{
+		ID:             delivery.ID,
+		Event:          spec.JarEventType(delivery.Event),
+		Status:         spec.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus.Int32,
+		LastError:      delivery.LastError.String,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	if delivery.Status == string(spec.DeliveryPending) {
+		out.NextAttemptAt = delivery.NextAttemptAt
+	}
+	err := json.Unmarshal(delivery.Payload, &out.Payload)
+	return out, err
+}
+
+func webhookEvents(events []spec.JarEventType) []string {
+	out := make([]string, len(events))
+	for i, e := range events {
+		out[i] = string(e)
+	}
+	return out
+}
+
+func (app *Application) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := app.createWebhook(w, r); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) createWebhook(w http.ResponseWriter, r *http.Request) error {
+	input := spec.CreateWebhookInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	user := app.contextGetUser(r)
+	if !user.Activated {
+		return errInactiveAccount
+	}
+
+	arg := database.InsertWebhookParams{
+		UserID: user.ID,
+		Url:    input.URL,
+		Secret: input.Secret,
+		Events: webhookEvents(input.Events),
+	}
+	if input.JarID != "" {
+		jar, err := app.requireJarRole(r, input.JarID, roleOwner)
+		if err != nil {
+			return err
+		}
+		arg.JarID = pgtype.Text{String: jar.ID, Valid: true}
+	}
+	webhook, err := app.store.InsertWebhook(r.Context(), arg)
+	if err != nil {
+		return err
+	}
+	return app.writeJSON(w, http.StatusOK, dbWebhookToSpec(webhook), nil)
+}
+
+func (app *Application) GetUserWebhooks(w http.ResponseWriter, r *http.Request) {
+	if err := app.getUserWebhooks(w, r); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getUserWebhooks(w http.ResponseWriter, r *http.Request) error {
+	webhooks, err := app.store.GetWebhooksByUser(r.Context(), app.contextGetUser(r).ID)
+	if err != nil {
+		return err
+	}
+	out := make(spec.WebhookCollection, len(webhooks))
+	for i, wh := range webhooks {
+		out[i] = dbWebhookToSpec(wh)
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
+
+func (app *Application) PatchWebhook(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID) {
+	if err := app.patchWebhook(w, r, webhookID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) patchWebhook(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID) error {
+	input := spec.WebhookPatchInput{}
+	if err := app.readJSON(w, r, &input); err != nil {
+		return errBadRequest(err)
+	}
+	v := input.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	user := app.contextGetUser(r)
+	webhook, err := app.store.GetWebhook(r.Context(), database.GetWebhookParams{
+		ID:     webhookID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		return dbErr(err)
+	}
+
+	arg := database.UpdateWebhookParams{
+		Url:      webhook.Url,
+		Secret:   webhook.Secret,
+		Events:   webhook.Events,
+		Active:   webhook.Active,
+		Failures: webhook.Failures,
+		ID:       webhook.ID,
+		UserID:   user.ID,
+	}
+	if input.URL != nil {
+		arg.Url = *input.URL
+	}
+	if input.Secret != nil {
+		arg.Secret = *input.Secret
+	}
+	if input.Events != nil {
+		arg.Events = webhookEvents(*input.Events)
+	}
+	if input.Active != nil {
+		// Turning a webhook back on gives it a clean slate, or the next
+		// failure would disable it again.
+		if *input.Active && !webhook.Active {
+			arg.Failures = 0
+		}
+		arg.Active = *input.Active
+	}
+	webhook, err = app.store.UpdateWebhook(r.Context(), arg)
+	if err != nil {
+		return dbErr(err)
+	}
+	return app.writeJSON(w, http.StatusOK, dbWebhookToSpec(webhook), nil)
+}
+
+func (app *Application) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID) {
+	if err := app.deleteWebhook(w, r, webhookID); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) deleteWebhook(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID) error {
+	n, err := app.store.DeleteWebhook(r.Context(), database.DeleteWebhookParams{
+		ID:     webhookID,
+		UserID: app.contextGetUser(r).ID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNotFound
+	}
+	return app.writeJSON(w, http.StatusOK, spec.Message{Message: "webhook deleted"}, nil)
+}
+
+func (app *Application) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID, params spec.GetWebhookDeliveriesParams) {
+	if err := app.getWebhookDeliveries(w, r, webhookID, params); err != nil {
+		app.handleError(w, r, err)
+	}
+}
+
+func (app *Application) getWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookID spec.WebhookID, params spec.GetWebhookDeliveriesParams) error {
+	v := params.Validate()
+	if !v.Valid() {
+		return errValidation(spec.ValidationError(*v))
+	}
+	webhook, err := app.store.GetWebhook(r.Context(), database.GetWebhookParams{
+		ID:     webhookID,
+		UserID: app.contextGetUser(r).ID,
+	})
+	if err != nil {
+		return dbErr(err)
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultPageLimit
+	}
+	deliveries, err := app.store.GetWebhookDeliveries(r.Context(), database.GetWebhookDeliveriesParams{
+		WebhookID: webhook.ID,
+		Limit:     int32(params.Limit),
+	})
+	if err != nil {
+		return err
+	}
+	out := make(spec.WebhookDeliveryCollection, len(deliveries))
+	for i, d := range deliveries {
+		if out[i], err = dbWebhookDeliveryToSpec(d); err != nil {
+			return err
+		}
+	}
+	return app.writeJSON(w, http.StatusOK, out, nil)
+}
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/render"
 	"github.com/kapilpokhrel/scrolljar/internal/spec"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -39,29 +44,76 @@ func scrollURI(id string) string {
 	return fmt.Sprintf("%s/scroll/%s", baseURI, id)
 }
 
+// scrollLinesURI is a permalink to a range of a scroll's lines: the rendered
+// view with the range highlighted, scrolled to the range's first line.
+func scrollLinesURI(id string, lines spec.LineRange) string {
+	return fmt.Sprintf("%s/render?lines=%s#%s%d", scrollURI(id), lines, render.LinePrefix, lines.Start)
+}
+
 func dbJarToSpec(jar database.Scrolljar) spec.Jar {
 	return spec.Jar{
-		ID:        jar.ID,
-		Name:      jar.Name.String,
-		Access:    spec.JarAccess(jar.Access),
-		Tags:      jar.Tags,
-		ExpiresAt: jar.ExpiresAt,
-		CreatedAt: jar.CreatedAt,
-		URI:       jarURI(jar.ID),
+		ID:         jar.ID,
+		Name:       jar.Name.String,
+		Slug:       jar.Slug.String,
+		ForkedFrom: jar.ForkedFrom.String,
+		Access:     spec.JarAccess(jar.Access),
+		Tags:       jar.Tags,
+		ExpiresAt:  jar.ExpiresAt,
+		CreatedAt:  jar.CreatedAt,
+		UpdatedAt:  jar.UpdatedAt,
+		URI:        jarURI(jar.ID),
 	}
 }
 
 func dbScrollToSpec(scroll database.Scroll) spec.Scroll {
 	return spec.Scroll{
-		ID:        scroll.ID,
-		JarID:     scroll.JarID,
-		Title:     scroll.Title.String,
-		Format:    scroll.Format.String,
-		CreatedAt: scroll.CreatedAt,
-		URI:       scrollURI(scroll.ID),
+		ID:               scroll.ID,
+		JarID:            scroll.JarID,
+		Title:            scroll.Title.String,
+		Format:           scroll.Format.String,
+		FormatConfidence: scroll.FormatConfidence.Float32,
+		Filename:         scroll.Filename.String,
+		Readme:           scroll.IsReadme,
+		Revision:         scroll.Revision,
+		Appendable:       scroll.Appendable,
+		Sealed:           scroll.Appendable && scroll.Uploaded,
+		Position:         scroll.Position,
+		CreatedAt:        scroll.CreatedAt,
+		URI:              scrollURI(scroll.ID),
 	}
 }
 
+const defaultPageLimit = 20
+
+// pageParams fills in the defaults for the common pagination parameters.
+func pageParams(limit int, cursor string, sort string, order spec.SortOrder) database.PageParams {
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	if sort == "" {
+		sort = "created_at"
+	}
+	if order == "" {
+		order = spec.SortDesc
+	}
+	return database.PageParams{
+		Limit:  int32(limit),
+		Sort:   sort,
+		Order:  string(order),
+		Cursor: cursor,
+	}
+}
+
+// pageErr maps an invalid cursor to a validation error on the cursor field.
+func pageErr(err error) error {
+	if errors.Is(err, database.ErrInvalidCursor) {
+		v := spec.NewValidator()
+		v.AddError(spec.FieldError{Field: []string{"cursor"}, Msg: "invalid cursor"})
+		return errValidation(spec.ValidationError(*v))
+	}
+	return err
+}
+
 func dbUserToSpec(user database.UserAccount) spec.User {
 	return spec.User{
 		ID:        user.ID,
@@ -77,6 +129,18 @@ func (app *Application) writeJSON(w http.ResponseWriter, status int, data any, h
 	return json.NewEncoder(w).Encode(data)
 }
 
+// writeHTML writes rendered scroll content. The HTML only ever needs its own
+// inline styles, so the policy shuts out scripts and anything else a scroll
+// might sneak in.
+func (app *Application) writeHTML(w http.ResponseWriter, status int, html []byte) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, err := w.Write(html)
+	return err
+}
+
 func (app *Application) readJSON(w http.ResponseWriter, r *http.Request, dst any) error {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
@@ -128,68 +192,98 @@ func (app *Application) backgroundTask(fn func(), taskname string) {
 
 var secretKey = []byte("<SECRET_KEY>")
 
-func createScrollUploadToken(scrollID, jarID string, user *database.UserAccount) (string, error) {
+const (
+	scrollUploadTokenTTL = 5 * time.Minute
+	// An appendable scroll's token is used for as long as whatever writes to
+	// the scroll keeps running.
+	scrollAppendTokenTTL = 24 * time.Hour
+)
+
+func createScrollUploadToken(scroll database.Scroll, user *database.UserAccount) (string, error) {
 	var userID int64 = -1
 	if user != nil && user.Activated {
 		userID = user.ID
 	}
+	ttl := scrollUploadTokenTTL
+	if scroll.Appendable {
+		ttl = scrollAppendTokenTTL
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"scrollID": scrollID,
-		"jarID":    jarID,
+		"scrollID": scroll.ID,
+		"jarID":    scroll.JarID,
 		"userID":   userID,
-		"exp":      time.Now().Add(time.Minute * 5).Unix(),
+		"jti":      rand.Text(),
+		"exp":      time.Now().Add(ttl).Unix(),
 	})
 	return token.SignedString(secretKey)
 }
 
-func verifyScrollUploadToken(tokenString string) (scrollID, jarID string, userID int64, err error) {
+// scrollUploadClaims are what an upload token was issued for.
+type scrollUploadClaims struct {
+	scrollID string
+	jarID    string
+	// userID is the user the token was issued to, or -1.
+	userID int64
+	// jti identifies the token, so its uses can be recorded.
+	jti string
+}
+
+func verifyScrollUploadToken(tokenString string) (scrollUploadClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		return secretKey, nil
 	})
 	if err != nil {
-		return "", "", -1, err
+		return scrollUploadClaims{}, err
 	}
 	if !token.Valid {
-		return "", "", -1, fmt.Errorf("invalid token")
+		return scrollUploadClaims{}, fmt.Errorf("invalid token")
 	}
 	claims := token.Claims.(jwt.MapClaims)
-	return claims["scrollID"].(string), claims["jarID"].(string), int64(claims["userID"].(float64)), nil
-}
-
-// checkJarPassword returns an error if the jar is private and the supplied password is wrong.
-func checkJarPassword(jar database.Scrolljar, password string) error {
-	if jar.Access != int16(spec.AccessPrivate) {
-		return nil
-	}
-	if password == "" || !verifyHashPassword(password, jar.PasswordHash.String) {
-		return errors.New("invalid jar password")
+	scrollID, ok1 := claims["scrollID"].(string)
+	jarID, ok2 := claims["jarID"].(string)
+	uid, ok3 := claims["userID"].(float64)
+	jti, ok4 := claims["jti"].(string)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return scrollUploadClaims{}, fmt.Errorf("invalid token")
 	}
-	return nil
+	return scrollUploadClaims{
+		scrollID: scrollID,
+		jarID:    jarID,
+		userID:   int64(uid),
+		jti:      jti,
+	}, nil
 }
 
-// isJarCreator checks whether the authenticated user owns the given jar.
-func (app *Application) isJarCreator(r *http.Request, jarID string) (bool, error) {
-	user := app.contextGetUser(r)
-	ownerID, err := app.store.GetJarOwnerID(r.Context(), jarID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return false, nil
-		}
-		return false, err
-	}
-	return ownerID.Valid && ownerID.Int64 == user.ID, nil
+const jarUnlockTokenTTL = 15 * time.Minute
+
+// createJarUnlockToken issues a token that reads the given private jar without its password.
+func createJarUnlockToken(jarID string) (string, time.Time, error) {
+	expiry := time.Now().Add(jarUnlockTokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"scope": "unlock",
+		"jarID": jarID,
+		"exp":   expiry.Unix(),
+	})
+	tokenString, err := token.SignedString(secretKey)
+	return tokenString, expiry, err
 }
 
-// requireJarCreator returns errInvalidCreds if the caller is not the jar owner.
-func (app *Application) requireJarCreator(r *http.Request, jarID string) error {
-	ok, err := app.isJarCreator(r, jarID)
+func verifyJarUnlockToken(tokenString string) (jarID string, err error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		return secretKey, nil
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
-	if !ok {
-		return errInvalidCreds
+	if !token.Valid {
+		return "", fmt.Errorf("invalid token")
 	}
-	return nil
+	claims := token.Claims.(jwt.MapClaims)
+	jarID, ok := claims["jarID"].(string)
+	if !ok || claims["scope"] != "unlock" {
+		return "", fmt.Errorf("invalid token")
+	}
+	return jarID, nil
 }
 
 // dbErr maps pgx.ErrNoRows to errNotFound; all other errors pass through as-is.
@@ -226,6 +320,74 @@ func (u utf8ValidationReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// copyLines copies the lines of src within lines to dst a buffer at a time,
+// returning how many lines src has in all. A final line without a newline
+// still counts.
+func copyLines(dst io.Writer, src io.Reader, lines spec.LineRange) (int, error) {
+	br := bufio.NewReader(src)
+	line, total := 1, 0
+	for {
+		// A line longer than the buffer comes back in several pieces, only
+		// the last of which ends in a newline.
+		chunk, err := br.ReadSlice('\n')
+		if len(chunk) > 0 {
+			if lines.Contains(line) {
+				if _, err := dst.Write(chunk); err != nil {
+					return total, err
+				}
+			}
+			total = line
+			if chunk[len(chunk)-1] == '\n' {
+				line++
+			}
+		}
+		switch {
+		case err == nil || errors.Is(err, bufio.ErrBufferFull):
+		case errors.Is(err, io.EOF):
+			return total, nil
+		default:
+			return total, err
+		}
+	}
+}
+
+// writeEvent writes a Server-Sent Event. Each line of data goes in a data
+// field of its own, and the client joins them back up with newlines.
+func writeEvent(w io.Writer, event, id, data string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "event: %s\n", event)
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	for line := range strings.SplitSeq(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// cappedBuffer keeps the first max bytes written to it and silently discards
+// the rest, so it can sit behind an io.TeeReader without limiting the stream.
+type cappedBuffer struct {
+	buf bytes.Buffer
+	max int
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	if room := c.max - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+// Text returns the captured bytes as valid UTF-8 without NUL characters,
+// which postgres can't store in a TEXT column.
+func (c *cappedBuffer) Text() string {
+	text := strings.ToValidUTF8(c.buf.String(), "")
+	return strings.ReplaceAll(text, "\x00", "")
+}
+
 // jarExpiryFromInput resolves the expiry for a new jar given the input and whether the user is authenticated.
 func jarExpiryFromInput(input spec.CreateJarInput, authenticated bool) pgtype.Timestamptz {
 	const durYear = time.Hour * 25 * 365
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kapilpokhrel/scrolljar/internal/spec"
+)
+
+func TestCopyLines(t *testing.T) {
+	long := strings.Repeat("x", 10000)
+	tests := []struct {
+		name      string
+		content   string
+		lines     spec.LineRange
+		want      string
+		wantTotal int
+	}{
+		{"empty", "", spec.LineRange{}, "", 0},
+		{"all", "a\nb\nc\n", spec.LineRange{}, "a\nb\nc\n", 3},
+		{"no final newline", "a\nb\nc", spec.LineRange{}, "a\nb\nc", 3},
+		{"single line", "a\nb\nc\n", spec.LineRange{Start: 2, End: 2}, "b\n", 3},
+		{"range", "a\nb\nc\nd\n", spec.LineRange{Start: 2, End: 3}, "b\nc\n", 4},
+		{"range to last line without newline", "a\nb\nc", spec.LineRange{Start: 2, End: 3}, "b\nc", 3},
+		{"range runs past EOF", "a\nb\nc\n", spec.LineRange{Start: 2, End: 10}, "b\nc\n", 3},
+		{"range starts past EOF", "a\nb\nc\n", spec.LineRange{Start: 5, End: 10}, "", 3},
+		{"long line", "a\n" + long + "\nc\n", spec.LineRange{Start: 2, End: 2}, long + "\n", 3},
+		{"after long line", "a\n" + long + "\nc\n", spec.LineRange{Start: 3, End: 3}, "c\n", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			total, err := copyLines(&b, strings.NewReader(tt.content), tt.lines)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("copied %q, want %q", b.String(), tt.want)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
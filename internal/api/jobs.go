@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kapilpokhrel/scrolljar/internal/jobs"
+)
+
+// activationMail welcomes a new user with the token that activates their
+// account.
+type activationMail struct {
+	UserID int64 `json:"user_id"`
+}
+
+// jarTransferMail tells the target of a transfer about it. Its fields are
+// the template's data.
+type jarTransferMail struct {
+	Recipient  string    `json:"recipient"`
+	From       string    `json:"from"`
+	JarID      string    `json:"jar_id"`
+	JarName    string    `json:"jar_name"`
+	TransferID int64     `json:"transfer_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+const (
+	activationMailJob  jobs.Kind[activationMail]  = "activation_mail"
+	jarTransferMailJob jobs.Kind[jarTransferMail] = "jar_transfer_mail"
+)
+
+func (app *Application) registerJobs() {
+	jobs.Handle(app.jobs, activationMailJob, app.sendActivationMail)
+	jobs.Handle(app.jobs, jarTransferMailJob, app.sendJarTransferMail)
+}
+
+// sendActivationMail makes the activation token when the mail is sent, so a
+// mail that had to be retried doesn't carry a token that already expired.
+func (app *Application) sendActivationMail(ctx context.Context, job activationMail) error {
+	user, err := app.store.GetUserByID(ctx, job.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if user.Activated {
+		return nil
+	}
+	tokenText, _, err := app.store.CreateActivationToken(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	userData := struct {
+		ID    int64
+		Token string
+		Email string
+	}{ID: user.ID, Token: tokenText, Email: user.Email}
+	return app.mailer.Send(user.Email, "user_verify.html", userData)
+}
+
+func (app *Application) sendJarTransferMail(ctx context.Context, job jarTransferMail) error {
+	return app.mailer.Send(job.Recipient, "jar_transfer.html", job)
+}
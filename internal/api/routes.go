@@ -40,7 +40,23 @@ func NewRouteIPLimiter(factory RateLimiterFactory) routeIPLimiter {
 		{"POST", regexp.MustCompile(`^/jar$`), "Medium", nil},
 		{"GET", regexp.MustCompile(`^/jar/[^/]+$`), "General", nil},
 		{"DELETE", regexp.MustCompile(`^/jar/[^/]+$`), "Medium", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/restore$`), "Medium", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/transfer$`), "Strict", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/fork$`), "Medium", nil},
+		{"PUT", regexp.MustCompile(`^/jar/[^/]+/slug$`), "Medium", nil},
+		{"PUT", regexp.MustCompile(`^/jar/[^/]+/order$`), "Medium", nil},
 		{"GET", regexp.MustCompile(`^/jar/[^/]+/scrolls$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/jar/[^/]+/events$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/unlock$`), "Strict", nil},
+		{"GET", regexp.MustCompile(`^/jar/[^/]+/members$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/members$`), "Medium", nil},
+		{"DELETE", regexp.MustCompile(`^/jar/[^/]+/members/[^/]+$`), "Medium", nil},
+		{"GET", regexp.MustCompile(`^/jar/[^/]+/share-links$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/jar/[^/]+/share-links$`), "Medium", nil},
+		{"DELETE", regexp.MustCompile(`^/jar/[^/]+/share-links/[^/]+$`), "Medium", nil},
+
+		{"POST", regexp.MustCompile(`^/transfer/[^/]+/accept$`), "Medium", nil},
+		{"DELETE", regexp.MustCompile(`^/transfer/[^/]+$`), "Medium", nil},
 
 		{"PUT", regexp.MustCompile(`^/upload$`), "Medium", nil},
 
@@ -48,14 +64,37 @@ func NewRouteIPLimiter(factory RateLimiterFactory) routeIPLimiter {
 		{"POST", regexp.MustCompile(`^/scroll/[^/]+$`), "Medium", nil},
 		{"PATCH", regexp.MustCompile(`^/scroll/[^/]+$`), "Medium", nil},
 		{"DELETE", regexp.MustCompile(`^/scroll/[^/]+$`), "Medium", nil},
+		{"GET", regexp.MustCompile(`^/scroll/[^/]+/render$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/scroll/[^/]+/raw$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/append$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/seal$`), "Medium", nil},
+		{"GET", regexp.MustCompile(`^/scroll/[^/]+/tail$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/scroll/[^/]+/comments$`), "General", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/comments$`), "Medium", nil},
+		{"PATCH", regexp.MustCompile(`^/scroll/[^/]+/comments/[^/]+$`), "Medium", nil},
+		{"DELETE", regexp.MustCompile(`^/scroll/[^/]+/comments/[^/]+$`), "Medium", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/restore$`), "Medium", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/move$`), "Medium", nil},
+		{"POST", regexp.MustCompile(`^/scroll/[^/]+/copy$`), "Medium", nil},
 
 		{"GET", regexp.MustCompile(`^/user$`), "Medium", nil},
 		{"POST", regexp.MustCompile(`^/user/auth$`), "Medium", nil},
 		{"POST", regexp.MustCompile(`^/user/register$`), "Strict", nil},
 		{"PUT", regexp.MustCompile(`^/user/activate$`), "Strict", nil},
 		{"GET", regexp.MustCompile(`^/user/jars$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/user/trash$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/user/transfers$`), "General", nil},
+		{"GET", regexp.MustCompile(`^/user/webhooks$`), "General", nil},
+
+		{"POST", regexp.MustCompile(`^/webhook$`), "Medium", nil},
+		{"PATCH", regexp.MustCompile(`^/webhook/[^/]+$`), "Medium", nil},
+		{"DELETE", regexp.MustCompile(`^/webhook/[^/]+$`), "Medium", nil},
+		{"GET", regexp.MustCompile(`^/webhook/[^/]+/deliveries$`), "General", nil},
 
 		{"POST", regexp.MustCompile(`^/token/activation$`), "Strict", nil},
+
+		{"GET", regexp.MustCompile(`^/search$`), "Medium", nil},
+		{"GET", regexp.MustCompile(`^/formats$`), "General", nil},
 	}
 	for i, p := range policies {
 		policies[i].mw = factory(p.level)
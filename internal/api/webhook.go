@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+	"github.com/kapilpokhrel/scrolljar/internal/jobs"
+)
+
+const (
+	webhookPollInterval = 5 * time.Second
+	webhookBatchSize    = 10
+	webhookTimeout      = 10 * time.Second
+	// A delivery is retried with exponential backoff, from 30 seconds up
+	// to 6 hours, which spreads 14 attempts over about a day.
+	webhookMaxAttempts  = 14
+	webhookRetryBackoff = 30 * time.Second
+	webhookMaxBackoff   = 6 * time.Hour
+	// After this many failed attempts in a row, across all of its
+	// deliveries, a webhook is disabled.
+	webhookDisableAfter = 20
+)
+
+var errPrivateAddress = errors.New("webhook URL resolves to a private address")
+
+// newWebhookClient makes the client deliveries are sent with. Outside of
+// development it refuses to connect to loopback and private addresses, so a
+// webhook can't be pointed at the server's own network.
+func newWebhookClient(env Environment) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if env != DEV {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			addr := addrPort.Addr().Unmap()
+			if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     time.Minute,
+		},
+		// A redirect counts as a failure rather than being followed
+		// somewhere the webhook's owner didn't register.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// signWebhook is the signature of a delivery's body, which receivers check
+// with the webhook's secret to tell deliveries from forgeries.
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// wakeWebhooks has the delivery worker look for deliveries right away
+// instead of at its next poll.
+func (app *Application) wakeWebhooks() {
+	select {
+	case app.webhookWake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverWebhooks sends the queued webhook deliveries until the server shuts
+// down. Deliveries in flight when it stops are retried once their lease runs
+// out, so a receiver may see one twice.
+func (app *Application) deliverWebhooks() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-app.closing
+		cancel()
+	}()
+
+	poll := time.NewTicker(webhookPollInterval)
+	defer poll.Stop()
+	for {
+		app.deliverDueWebhooks(ctx)
+		select {
+		case <-poll.C:
+		case <-app.webhookWake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliverDueWebhooks sends every delivery that is due, a batch at a time.
+func (app *Application) deliverDueWebhooks(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := app.store.ClaimWebhookDeliveries(ctx, webhookBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				app.logger.Error(err.Error())
+			}
+			return
+		}
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Go(func() { app.deliverWebhook(delivery) })
+		}
+		wg.Wait()
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// deliverWebhook sends a delivery and records how it went. Any 2xx response
+// counts as delivered.
+func (app *Application) deliverWebhook(delivery database.ClaimWebhookDeliveriesRow) {
+	// The delivery is finished even while shutting down, so it isn't sent
+	// again after its lease runs out.
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout+5*time.Second)
+	defer cancel()
+
+	status, err := app.sendWebhook(ctx, delivery)
+	if err == nil {
+		if err := app.store.CompleteWebhookDelivery(ctx, delivery, int32(status)); err != nil {
+			app.logger.Error(err.Error(), "delivery", delivery.ID)
+		}
+		return
+	}
+
+	arg := database.SetWebhookDeliveryFailedParams{
+		MaxAttempts:   webhookMaxAttempts,
+		NextAttemptAt: pgtype.Timestamptz{Time: time.Now().Add(jobs.Backoff(delivery.Attempts, webhookRetryBackoff, webhookMaxBackoff)), Valid: true},
+		LastError:     pgtype.Text{String: err.Error(), Valid: true},
+		ID:            delivery.ID,
+	}
+	if status != 0 {
+		arg.ResponseStatus = pgtype.Int4{Int32: int32(status), Valid: true}
+	}
+	if err := app.store.FailWebhookDelivery(ctx, arg, delivery.WebhookID, webhookDisableAfter); err != nil {
+		app.logger.Error(err.Error(), "delivery", delivery.ID)
+	}
+}
+
+// sendWebhook posts a delivery to its webhook, returning the response status
+// if there was a response.
+func (app *Application) sendWebhook(ctx context.Context, delivery database.ClaimWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Scrolljar-Webhook/1.0")
+	req.Header.Set("X-Scrolljar-Event", delivery.Event)
+	req.Header.Set("X-Scrolljar-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Scrolljar-Signature", signWebhook(delivery.Secret, delivery.Payload))
+
+	res, err := app.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// Draining a little of the body lets the connection be reused.
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
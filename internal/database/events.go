@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// JarEventChannel is the channel the triggers on scroll and scrolljar notify
+// of changes on, so that every API server hears of them.
+const JarEventChannel = "jar_events"
+
+// ScrollAppendedEvent is sent on JarEventChannel for each chunk appended to a
+// scroll. It only wakes the scroll's tails and isn't a jar event of its own.
+const ScrollAppendedEvent = "scroll.appended"
+
+// JarEvent is a change to a jar or one of its scrolls. ScrollID is empty for
+// changes to the jar itself.
+type JarEvent struct {
+	Type     string `json:"type"`
+	JarID    string `json:"jar_id"`
+	ScrollID string `json:"scroll_id"`
+}
+
+// ListenJarEvents calls handle with each jar event until ctx is done or the
+// connection fails. It holds a connection of its own for as long as it runs.
+func (s *Store) ListenJarEvents(ctx context.Context, handle func(JarEvent)) error {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A connection that has been listening isn't fit to go back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+JarEventChannel); err != nil {
+		return err
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var event JarEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			continue
+		}
+		handle(event)
+	}
+}
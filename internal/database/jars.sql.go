@@ -11,30 +11,40 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const deleteExpiredJars = `-- name: DeleteExpiredJars :exec
+const deleteExpiredJars = `-- name: DeleteExpiredJars :execrows
 DELETE FROM scrolljar WHERE expires_at <= now()
 `
 
-func (q *Queries) DeleteExpiredJars(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteExpiredJars)
-	return err
+func (q *Queries) DeleteExpiredJars(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredJars)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deleteJar = `-- name: DeleteJar :exec
-DELETE FROM scrolljar WHERE id = $1
+const deleteSlugRedirect = `-- name: DeleteSlugRedirect :exec
+DELETE FROM jar_slug_redirect WHERE slug = $1 AND jar_id = $2
 `
 
-func (q *Queries) DeleteJar(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, deleteJar, id)
+type DeleteSlugRedirectParams struct {
+	Slug  string
+	JarID string
+}
+
+func (q *Queries) DeleteSlugRedirect(ctx context.Context, arg DeleteSlugRedirectParams) error {
+	_, err := q.db.Exec(ctx, deleteSlugRedirect, arg.Slug, arg.JarID)
 	return err
 }
 
 const getJar = `-- name: GetJar :one
-SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
 FROM scrolljar
-WHERE id = $1 AND (expires_at IS NULL OR expires_at > now())
+WHERE (id = $1 OR slug = $1 OR id = (SELECT jar_id FROM jar_slug_redirect WHERE jar_slug_redirect.slug = $1))
+    AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
 `
 
+// Jars are found by ID, by slug, or by a slug they used to have.
 func (q *Queries) GetJar(ctx context.Context, id string) (Scrolljar, error) {
 	row := q.db.QueryRow(ctx, getJar, id)
 	var i Scrolljar
@@ -48,13 +58,28 @@ func (q *Queries) GetJar(ctx context.Context, id string) (Scrolljar, error) {
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.ForkedFrom,
 	)
 	return i, err
 }
 
+const getJarForkCount = `-- name: GetJarForkCount :one
+SELECT COUNT(*) FROM scrolljar
+WHERE forked_from = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+`
+
+func (q *Queries) GetJarForkCount(ctx context.Context, forkedFrom pgtype.Text) (int64, error) {
+	row := q.db.QueryRow(ctx, getJarForkCount, forkedFrom)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getJarOwnerID = `-- name: GetJarOwnerID :one
 SELECT user_id FROM scrolljar
-WHERE id = $1 AND (expires_at IS NULL OR expires_at > now())
+WHERE id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
 `
 
 func (q *Queries) GetJarOwnerID(ctx context.Context, id string) (pgtype.Int8, error) {
@@ -65,9 +90,9 @@ func (q *Queries) GetJarOwnerID(ctx context.Context, id string) (pgtype.Int8, er
 }
 
 const getJarsByUser = `-- name: GetJarsByUser :many
-SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
 FROM scrolljar
-WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > now())
+WHERE user_id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
 `
 
 func (q *Queries) GetJarsByUser(ctx context.Context, userID pgtype.Int8) ([]Scrolljar, error) {
@@ -89,6 +114,89 @@ func (q *Queries) GetJarsByUser(ctx context.Context, userID pgtype.Int8) ([]Scro
 			&i.ExpiresAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.ForkedFrom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrashedJarForUpdate = `-- name: GetTrashedJarForUpdate :one
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
+FROM scrolljar
+WHERE id = $1 AND user_id = $2 AND deleted_at > $3
+    AND (expires_at IS NULL OR expires_at > now())
+FOR UPDATE
+`
+
+type GetTrashedJarForUpdateParams struct {
+	ID           string
+	UserID       pgtype.Int8
+	DeletedAfter pgtype.Timestamptz
+}
+
+func (q *Queries) GetTrashedJarForUpdate(ctx context.Context, arg GetTrashedJarForUpdateParams) (Scrolljar, error) {
+	row := q.db.QueryRow(ctx, getTrashedJarForUpdate, arg.ID, arg.UserID, arg.DeletedAfter)
+	var i Scrolljar
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Access,
+		&i.PasswordHash,
+		&i.Tags,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.ForkedFrom,
+	)
+	return i, err
+}
+
+const getTrashedJarsByUser = `-- name: GetTrashedJarsByUser :many
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
+FROM scrolljar
+WHERE user_id = $1 AND deleted_at > $2
+    AND (expires_at IS NULL OR expires_at > now())
+ORDER BY deleted_at DESC, id
+`
+
+type GetTrashedJarsByUserParams struct {
+	UserID       pgtype.Int8
+	DeletedAfter pgtype.Timestamptz
+}
+
+func (q *Queries) GetTrashedJarsByUser(ctx context.Context, arg GetTrashedJarsByUserParams) ([]Scrolljar, error) {
+	rows, err := q.db.Query(ctx, getTrashedJarsByUser, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Scrolljar
+	for rows.Next() {
+		var i Scrolljar
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.Access,
+			&i.PasswordHash,
+			&i.Tags,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Slug,
+			&i.ForkedFrom,
 		); err != nil {
 			return nil, err
 		}
@@ -101,9 +209,9 @@ func (q *Queries) GetJarsByUser(ctx context.Context, userID pgtype.Int8) ([]Scro
 }
 
 const insertJar = `-- name: InsertJar :one
-INSERT INTO scrolljar (id, user_id, name, access, password_hash, tags, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at
+INSERT INTO scrolljar (id, user_id, name, access, password_hash, tags, expires_at, forked_from)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
 `
 
 type InsertJarParams struct {
@@ -114,6 +222,7 @@ type InsertJarParams struct {
 	PasswordHash pgtype.Text
 	Tags         []string
 	ExpiresAt    pgtype.Timestamptz
+	ForkedFrom   pgtype.Text
 }
 
 func (q *Queries) InsertJar(ctx context.Context, arg InsertJarParams) (Scrolljar, error) {
@@ -125,6 +234,7 @@ func (q *Queries) InsertJar(ctx context.Context, arg InsertJarParams) (Scrolljar
 		arg.PasswordHash,
 		arg.Tags,
 		arg.ExpiresAt,
+		arg.ForkedFrom,
 	)
 	var i Scrolljar
 	err := row.Scan(
@@ -137,6 +247,105 @@ func (q *Queries) InsertJar(ctx context.Context, arg InsertJarParams) (Scrolljar
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.ForkedFrom,
 	)
 	return i, err
 }
+
+const insertSlugRedirect = `-- name: InsertSlugRedirect :exec
+INSERT INTO jar_slug_redirect (slug, jar_id) VALUES ($1, $2)
+`
+
+type InsertSlugRedirectParams struct {
+	Slug  string
+	JarID string
+}
+
+func (q *Queries) InsertSlugRedirect(ctx context.Context, arg InsertSlugRedirectParams) error {
+	_, err := q.db.Exec(ctx, insertSlugRedirect, arg.Slug, arg.JarID)
+	return err
+}
+
+const purgeTrashedJars = `-- name: PurgeTrashedJars :execrows
+DELETE FROM scrolljar WHERE deleted_at <= $1
+`
+
+func (q *Queries) PurgeTrashedJars(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedJars, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreJar = `-- name: RestoreJar :exec
+UPDATE scrolljar SET deleted_at = NULL WHERE id = $1
+`
+
+func (q *Queries) RestoreJar(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, restoreJar, id)
+	return err
+}
+
+const setJarSlug = `-- name: SetJarSlug :one
+UPDATE scrolljar SET slug = $2 WHERE id = $1
+RETURNING id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
+`
+
+type SetJarSlugParams struct {
+	ID   string
+	Slug pgtype.Text
+}
+
+func (q *Queries) SetJarSlug(ctx context.Context, arg SetJarSlugParams) (Scrolljar, error) {
+	row := q.db.QueryRow(ctx, setJarSlug, arg.ID, arg.Slug)
+	var i Scrolljar
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Access,
+		&i.PasswordHash,
+		&i.Tags,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Slug,
+		&i.ForkedFrom,
+	)
+	return i, err
+}
+
+const touchJar = `-- name: TouchJar :one
+UPDATE scrolljar SET updated_at = now()
+WHERE id = $1 AND updated_at = $2
+RETURNING updated_at
+`
+
+type TouchJarParams struct {
+	ID        string
+	UpdatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) TouchJar(ctx context.Context, arg TouchJarParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, touchJar, arg.ID, arg.UpdatedAt)
+	var updated_at pgtype.Timestamptz
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
+const trashJar = `-- name: TrashJar :execrows
+UPDATE scrolljar SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) TrashJar(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, trashJar, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// The list queries below need a dynamic ORDER BY and keyset predicate,
+// which sqlc can't express, so they are built by hand. Column lists must
+// stay in the same order as the generated models.
+
+const jarColumns = "j.id, j.name, j.user_id, j.access, j.password_hash, j.tags, j.expires_at, j.created_at, j.updated_at, j.deleted_at, j.slug, j.forked_from"
+
+const scrollColumns = "s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes"
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// sortKey is a sortable expression along with the type its cursor value is cast to.
+type sortKey struct {
+	expr string
+	cast string
+}
+
+var jarSortKeys = map[string]sortKey{
+	"created_at": {"j.created_at", "TIMESTAMPTZ"},
+	"updated_at": {"j.updated_at", "TIMESTAMPTZ"},
+	"expires_at": {"COALESCE(j.expires_at, 'infinity'::TIMESTAMPTZ)", "TIMESTAMPTZ"},
+	"name":       {"COALESCE(j.name, '')", "TEXT"},
+}
+
+var scrollSortKeys = map[string]sortKey{
+	"created_at": {"s.created_at", "TIMESTAMPTZ"},
+	"updated_at": {"s.updated_at", "TIMESTAMPTZ"},
+	"name":       {"COALESCE(s.title, '')", "TEXT"},
+	"position":   {"s.position", "INT"},
+}
+
+// Cursor points just past the last item of a page. It records the sort it
+// was issued for so that it can't be replayed against a different ordering.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"i"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// PageParams are the common keyset pagination parameters.
+type PageParams struct {
+	Limit  int32
+	Sort   string
+	Order  string
+	Cursor string
+}
+
+// Page is a single page of a list along with the total number of matching items.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      int64
+}
+
+type ListUserJarsParams struct {
+	PageParams
+	UserID         int64
+	Access         *int16
+	HasPassword    *bool
+	ExpiringBefore *time.Time
+}
+
+type ListJarScrollsParams struct {
+	PageParams
+	JarID string
+}
+
+// listQuery accumulates WHERE clauses and their positional arguments.
+type listQuery struct {
+	where []string
+	args  []any
+}
+
+func (q *listQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *listQuery) and(clause string) {
+	q.where = append(q.where, clause)
+}
+
+func (q *listQuery) whereSQL() string {
+	return strings.Join(q.where, " AND ")
+}
+
+// page runs the count query and the keyset page query for a filtered list.
+// scan must read the selected columns followed by the sort value.
+func page[T any](
+	ctx context.Context,
+	db DBTX,
+	from string,
+	columns string,
+	q listQuery,
+	keys map[string]sortKey,
+	p PageParams,
+	idExpr string,
+	scan func(pgx.Row, *T, *string) error,
+	id func(T) string,
+) (Page[T], error) {
+	var result Page[T]
+	key, ok := keys[p.Sort]
+	if !ok {
+		return result, fmt.Errorf("unsupported sort %q", p.Sort)
+	}
+
+	if err := db.QueryRow(ctx, "SELECT COUNT(*) FROM "+from+" WHERE "+q.whereSQL(), q.args...).Scan(&result.Total); err != nil {
+		return result, err
+	}
+
+	cmp, dir := "<", "DESC"
+	if p.Order == "asc" {
+		cmp, dir = ">", "ASC"
+	}
+	if p.Cursor != "" {
+		c, err := DecodeCursor(p.Cursor)
+		if err != nil || c.Sort != p.Sort || c.Order != p.Order {
+			return result, ErrInvalidCursor
+		}
+		q.and(fmt.Sprintf("(%s, %s) %s (%s::%s, %s)", key.expr, idExpr, cmp, q.arg(c.Value), key.cast, q.arg(c.ID)))
+	}
+
+	sql := fmt.Sprintf(
+		"SELECT %s, (%s)::TEXT FROM %s WHERE %s ORDER BY %s %s, %s %s LIMIT %s",
+		columns, key.expr, from, q.whereSQL(), key.expr, dir, idExpr, dir, q.arg(p.Limit+1),
+	)
+	rows, err := db.Query(ctx, sql, q.args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	var lastValue string
+	for rows.Next() {
+		var item T
+		var value string
+		if err := scan(rows, &item, &value); err != nil {
+			return result, err
+		}
+		if int32(len(result.Items)) == p.Limit {
+			result.NextCursor = Cursor{
+				Sort:  p.Sort,
+				Order: p.Order,
+				Value: lastValue,
+				ID:    id(result.Items[len(result.Items)-1]),
+			}.Encode()
+			break
+		}
+		result.Items = append(result.Items, item)
+		lastValue = value
+	}
+	return result, rows.Err()
+}
+
+// ListUserJars returns a page of the user's live jars.
+func (s *Store) ListUserJars(ctx context.Context, arg ListUserJarsParams) (Page[Scrolljar], error) {
+	var q listQuery
+	q.and("j.user_id = " + q.arg(arg.UserID))
+	q.and("j.deleted_at IS NULL")
+	q.and("(j.expires_at IS NULL OR j.expires_at > now())")
+	if arg.Access != nil {
+		q.and("j.access = " + q.arg(*arg.Access))
+	}
+	if arg.HasPassword != nil {
+		q.and("(j.password_hash IS NOT NULL) = " + q.arg(*arg.HasPassword))
+	}
+	if arg.ExpiringBefore != nil {
+		q.and("j.expires_at < " + q.arg(*arg.ExpiringBefore))
+	}
+	return page(ctx, s.pool, "scrolljar j", jarColumns, q, jarSortKeys, arg.PageParams, "j.id",
+		func(row pgx.Row, i *Scrolljar, value *string) error {
+			return row.Scan(
+				&i.ID,
+				&i.Name,
+				&i.UserID,
+				&i.Access,
+				&i.PasswordHash,
+				&i.Tags,
+				&i.ExpiresAt,
+				&i.CreatedAt,
+				&i.UpdatedAt,
+				&i.DeletedAt,
+				&i.Slug,
+				&i.ForkedFrom,
+				value,
+			)
+		},
+		func(j Scrolljar) string { return j.ID },
+	)
+}
+
+// ListJarScrolls returns a page of the uploaded scrolls of a live jar.
+func (s *Store) ListJarScrolls(ctx context.Context, arg ListJarScrollsParams) (Page[Scroll], error) {
+	var q listQuery
+	q.and("s.jar_id = " + q.arg(arg.JarID))
+	q.and("s.uploaded = TRUE")
+	q.and("s.deleted_at IS NULL AND j.deleted_at IS NULL")
+	q.and("(j.expires_at IS NULL OR j.expires_at > now())")
+	return page(ctx, s.pool, "scroll s JOIN scrolljar j ON j.id = s.jar_id", scrollColumns, q, scrollSortKeys, arg.PageParams, "s.id",
+		func(row pgx.Row, i *Scroll, value *string) error {
+			return row.Scan(
+				&i.ID,
+				&i.JarID,
+				&i.Title,
+				&i.Format,
+				&i.Uploaded,
+				&i.CreatedAt,
+				&i.UpdatedAt,
+				&i.DeletedAt,
+				&i.Position,
+				&i.Filename,
+				&i.FormatConfidence,
+				&i.IsReadme,
+				&i.Revision,
+				&i.Appendable,
+				&i.AppendedBytes,
+				value,
+			)
+		},
+		func(s Scroll) string { return s.ID },
+	)
+}
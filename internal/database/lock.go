@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// lockCheckInterval is how often the connection holding an advisory lock is
+// checked on.
+const lockCheckInterval = 15 * time.Second
+
+// WithAdvisoryLock calls fn while holding the session-level advisory lock
+// key, returning false without calling fn if another session holds it. The
+// lock goes with the connection that holds it, so fn's context is cancelled
+// if that connection is lost.
+func (s *Store) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context)) (bool, error) {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	// Closing the connection releases the lock, however fn ends.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(fnCtx)
+	}()
+
+	check := time.NewTicker(lockCheckInterval)
+	defer check.Stop()
+	for {
+		select {
+		case <-done:
+			return true, nil
+		case <-check.C:
+			if err := conn.Ping(ctx); err != nil {
+				cancel()
+				<-done
+				return true, err
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS scroll_content (
+    scroll_id CHAR(8) PRIMARY KEY REFERENCES scroll(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    body_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', body)) STORED
+);
+
+CREATE INDEX IF NOT EXISTS scroll_content_body_tsv_idx ON scroll_content USING GIN (body_tsv);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS scroll_content_body_tsv_idx;
+DROP TABLE IF EXISTS scroll_content;
+-- +goose StatementEnd
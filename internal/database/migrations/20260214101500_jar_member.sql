@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jar_member (
+    jar_id CHAR(8) NOT NULL REFERENCES scrolljar(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (jar_id, user_id),
+    CONSTRAINT jar_member_role_check CHECK (role IN ('viewer', 'editor'))
+);
+
+CREATE INDEX IF NOT EXISTS jar_member_user_id_idx ON jar_member(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS jar_member_user_id_idx;
+DROP TABLE IF EXISTS jar_member;
+-- +goose StatementEnd
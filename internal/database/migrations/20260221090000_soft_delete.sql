@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scrolljar ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE scroll ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS scrolljar_deleted_at_idx ON scrolljar(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS scroll_deleted_at_idx ON scroll(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Soft-delete counterpart of delete_empty_scrolljar: trashing the last live
+-- scroll of a jar trashes the jar with the same timestamp, so that restoring
+-- the jar can bring that scroll back with it.
+CREATE OR REPLACE FUNCTION trash_empty_scrolljar()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM scroll WHERE jar_id = NEW.jar_id AND deleted_at IS NULL LIMIT 1
+  ) THEN
+    UPDATE scrolljar SET deleted_at = NEW.deleted_at
+    WHERE id = NEW.jar_id AND deleted_at IS NULL;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trash_empty_scrolljar_trigger
+AFTER UPDATE OF deleted_at ON scroll
+FOR EACH ROW
+WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL)
+EXECUTE FUNCTION trash_empty_scrolljar();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trash_empty_scrolljar_trigger ON scroll;
+DROP FUNCTION IF EXISTS trash_empty_scrolljar();
+
+DROP INDEX IF EXISTS scroll_deleted_at_idx;
+DROP INDEX IF EXISTS scrolljar_deleted_at_idx;
+
+ALTER TABLE scroll DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE scrolljar DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jar_transfer (
+    id BIGSERIAL PRIMARY KEY,
+    jar_id CHAR(8) NOT NULL UNIQUE REFERENCES scrolljar(id) ON DELETE CASCADE,
+    from_user_id BIGINT NOT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+    to_user_id BIGINT NOT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CONSTRAINT jar_transfer_users_check CHECK (from_user_id <> to_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS jar_transfer_from_user_id_idx ON jar_transfer(from_user_id);
+CREATE INDEX IF NOT EXISTS jar_transfer_to_user_id_idx ON jar_transfer(to_user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS jar_transfer_to_user_id_idx;
+DROP INDEX IF EXISTS jar_transfer_from_user_id_idx;
+DROP TABLE IF EXISTS jar_transfer;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scrolljar ADD COLUMN IF NOT EXISTS slug TEXT UNIQUE;
+
+-- Slugs a jar used to have keep resolving to it, so changing a slug doesn't
+-- break links that were already shared.
+CREATE TABLE IF NOT EXISTS jar_slug_redirect (
+    slug TEXT PRIMARY KEY,
+    jar_id CHAR(8) NOT NULL REFERENCES scrolljar(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS jar_slug_redirect_jar_id_idx ON jar_slug_redirect(jar_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS jar_slug_redirect_jar_id_idx;
+DROP TABLE IF EXISTS jar_slug_redirect;
+ALTER TABLE scrolljar DROP COLUMN IF EXISTS slug;
+-- +goose StatementEnd
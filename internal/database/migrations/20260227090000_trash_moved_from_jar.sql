@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Moving the last live scroll out of a jar leaves it as empty as trashing that
+-- scroll would, so the source jar is trashed in both cases.
+CREATE OR REPLACE FUNCTION trash_empty_scrolljar()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM scroll WHERE jar_id = OLD.jar_id AND deleted_at IS NULL LIMIT 1
+  ) THEN
+    UPDATE scrolljar SET deleted_at = COALESCE(NEW.deleted_at, now())
+    WHERE id = OLD.jar_id AND deleted_at IS NULL;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trash_empty_scrolljar_trigger ON scroll;
+CREATE TRIGGER trash_empty_scrolljar_trigger
+AFTER UPDATE OF deleted_at, jar_id ON scroll
+FOR EACH ROW
+WHEN (
+  (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL)
+  OR (OLD.jar_id IS DISTINCT FROM NEW.jar_id AND OLD.deleted_at IS NULL)
+)
+EXECUTE FUNCTION trash_empty_scrolljar();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION trash_empty_scrolljar()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM scroll WHERE jar_id = NEW.jar_id AND deleted_at IS NULL LIMIT 1
+  ) THEN
+    UPDATE scrolljar SET deleted_at = NEW.deleted_at
+    WHERE id = NEW.jar_id AND deleted_at IS NULL;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trash_empty_scrolljar_trigger ON scroll;
+CREATE TRIGGER trash_empty_scrolljar_trigger
+AFTER UPDATE OF deleted_at ON scroll
+FOR EACH ROW
+WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL)
+EXECUTE FUNCTION trash_empty_scrolljar();
+-- +goose StatementEnd
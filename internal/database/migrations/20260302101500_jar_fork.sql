@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scrolljar ADD COLUMN IF NOT EXISTS forked_from CHAR(8) REFERENCES scrolljar(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS scrolljar_forked_from_idx ON scrolljar(forked_from) WHERE forked_from IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS scrolljar_forked_from_idx;
+ALTER TABLE scrolljar DROP COLUMN IF EXISTS forked_from;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scroll ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+UPDATE scroll s SET position = o.position
+FROM (
+    SELECT id, (ROW_NUMBER() OVER (PARTITION BY jar_id ORDER BY created_at, id) - 1)::INT AS position
+    FROM scroll
+) o
+WHERE s.id = o.id;
+
+CREATE INDEX scroll_jar_position_idx ON scroll (jar_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS scroll_jar_position_idx;
+ALTER TABLE scroll DROP COLUMN IF EXISTS position;
+-- +goose StatementEnd
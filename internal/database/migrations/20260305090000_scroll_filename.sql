@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scroll ADD COLUMN filename TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE scroll DROP COLUMN IF EXISTS filename;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL unless the format was guessed from the scroll's content.
+ALTER TABLE scroll ADD COLUMN format_confidence REAL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE scroll DROP COLUMN IF EXISTS format_confidence;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scroll ADD COLUMN is_readme BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A jar has at most one README. Trashed scrolls keep the flag so that
+-- restoring them doesn't need to check for a newer README.
+CREATE UNIQUE INDEX scroll_jar_readme_idx ON scroll (jar_id) WHERE is_readme;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS scroll_jar_readme_idx;
+ALTER TABLE scroll DROP COLUMN IF EXISTS is_readme;
+-- +goose StatementEnd
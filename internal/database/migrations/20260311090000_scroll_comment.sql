@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A scroll's revision counts the times its content has been written, so
+-- comments can tell which version of the content they were made on.
+ALTER TABLE scroll ADD COLUMN revision INT NOT NULL DEFAULT 0;
+UPDATE scroll SET revision = 1 WHERE uploaded;
+
+CREATE TABLE IF NOT EXISTS scroll_comment (
+    id BIGSERIAL PRIMARY KEY,
+    scroll_id CHAR(8) NOT NULL REFERENCES scroll(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES user_account(id) ON DELETE SET NULL,
+    revision INT NOT NULL,
+    line_start INT NOT NULL,
+    line_end INT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT scroll_comment_lines_check CHECK (line_start >= 1 AND line_end >= line_start)
+);
+
+CREATE INDEX IF NOT EXISTS scroll_comment_scroll_id_idx ON scroll_comment(scroll_id, line_start);
+
+CREATE TRIGGER set_scroll_comment_updated_at
+BEFORE UPDATE ON scroll_comment
+FOR EACH ROW
+EXECUTE PROCEDURE set_update_timestamp();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scroll_comment;
+ALTER TABLE scroll DROP COLUMN IF EXISTS revision;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE scroll ADD COLUMN appendable BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE scroll ADD COLUMN appended_bytes BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE scroll DROP COLUMN IF EXISTS appended_bytes;
+ALTER TABLE scroll DROP COLUMN IF EXISTS appendable;
+-- +goose StatementEnd
@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_jar_event(event_type TEXT, jar_id TEXT, scroll_id TEXT)
+RETURNS VOID AS $$
+BEGIN
+  PERFORM pg_notify('jar_events', json_build_object(
+    'type', event_type,
+    'jar_id', jar_id,
+    'scroll_id', scroll_id
+  )::TEXT);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_scroll_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'INSERT') THEN
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+  ELSIF (TG_OP = 'DELETE') THEN
+    -- Trashed scrolls were reported when they were trashed.
+    IF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    END IF;
+  ELSIF OLD.jar_id <> NEW.jar_id THEN
+    PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+    IF NEW.uploaded THEN
+      PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('scroll.deleted', NEW.jar_id, NEW.id);
+  ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+    PERFORM notify_jar_event('scroll.restored', NEW.jar_id, NEW.id);
+  ELSIF NOT OLD.uploaded AND NEW.uploaded THEN
+    PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+  -- Appends and the jar's bookkeeping aren't reported.
+  ELSIF NEW.uploaded AND (OLD.title, OLD.format, OLD.filename, OLD.is_readme, OLD.position, OLD.revision)
+      IS DISTINCT FROM (NEW.title, NEW.format, NEW.filename, NEW.is_readme, NEW.position, NEW.revision) THEN
+    PERFORM notify_jar_event('scroll.updated', NEW.jar_id, NEW.id);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_scroll_change_trigger
+AFTER INSERT OR UPDATE OR DELETE ON scroll
+FOR EACH ROW
+EXECUTE FUNCTION notify_scroll_change();
+
+CREATE OR REPLACE FUNCTION notify_scrolljar_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'DELETE') THEN
+    IF OLD.expires_at <= now() THEN
+      PERFORM notify_jar_event('jar.expired', OLD.id, NULL);
+    ELSIF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('jar.deleted', OLD.id, NULL);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('jar.deleted', NEW.id, NULL);
+  -- Scroll changes touch the jar too, but are reported on their own.
+  ELSIF (OLD.name, OLD.user_id, OLD.access, OLD.password_hash, OLD.tags, OLD.expires_at, OLD.slug)
+      IS DISTINCT FROM (NEW.name, NEW.user_id, NEW.access, NEW.password_hash, NEW.tags, NEW.expires_at, NEW.slug) THEN
+    PERFORM notify_jar_event('jar.updated', NEW.id, NULL);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_scrolljar_change_trigger
+AFTER UPDATE OR DELETE ON scrolljar
+FOR EACH ROW
+EXECUTE FUNCTION notify_scrolljar_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS notify_scrolljar_change_trigger ON scrolljar;
+DROP TRIGGER IF EXISTS notify_scroll_change_trigger ON scroll;
+DROP FUNCTION IF EXISTS notify_scrolljar_change();
+DROP FUNCTION IF EXISTS notify_scroll_change();
+DROP FUNCTION IF EXISTS notify_jar_event(TEXT, TEXT, TEXT);
+-- +goose StatementEnd
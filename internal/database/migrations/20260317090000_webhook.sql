@@ -0,0 +1,142 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_account(id) ON DELETE CASCADE,
+    -- Without a jar, the webhook hears about every jar the user owns.
+    jar_id CHAR(8) REFERENCES scrolljar(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    -- No events means every event.
+    events TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failures INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_user_id_idx ON webhook(user_id);
+CREATE INDEX IF NOT EXISTS webhook_jar_id_idx ON webhook(jar_id);
+
+CREATE TRIGGER set_webhook_updated_at
+BEFORE UPDATE ON webhook
+FOR EACH ROW
+EXECUTE PROCEDURE set_update_timestamp();
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    response_status INT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT webhook_delivery_status_check CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_id_idx ON webhook_delivery(webhook_id, id);
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery(next_attempt_at) WHERE status = 'pending';
+
+-- Deliveries are queued in the transaction that made the change, so none are
+-- lost and none go out for changes that were rolled back.
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries(event_type TEXT, jar_id TEXT, owner_id BIGINT, scroll_id TEXT)
+RETURNS VOID AS $$
+BEGIN
+  INSERT INTO webhook_delivery (webhook_id, event, payload)
+  SELECT w.id, event_type, jsonb_strip_nulls(jsonb_build_object(
+    'type', event_type,
+    'jar_id', enqueue_webhook_deliveries.jar_id,
+    'scroll_id', scroll_id
+  ))
+  FROM webhook w
+  WHERE w.active
+    AND (w.jar_id = enqueue_webhook_deliveries.jar_id OR (w.jar_id IS NULL AND w.user_id = owner_id))
+    AND (cardinality(w.events) = 0 OR event_type = ANY(w.events));
+END;
+$$ LANGUAGE plpgsql;
+
+-- The jar's owner is given by jars that are being deleted, and looked up
+-- otherwise.
+DROP FUNCTION IF EXISTS notify_jar_event(TEXT, TEXT, TEXT);
+CREATE OR REPLACE FUNCTION notify_jar_event(event_type TEXT, jar_id TEXT, scroll_id TEXT, owner_id BIGINT DEFAULT NULL)
+RETURNS VOID AS $$
+BEGIN
+  PERFORM pg_notify('jar_events', json_build_object(
+    'type', event_type,
+    'jar_id', jar_id,
+    'scroll_id', scroll_id
+  )::TEXT);
+  IF owner_id IS NULL THEN
+    SELECT j.user_id INTO owner_id FROM scrolljar j WHERE j.id = notify_jar_event.jar_id;
+  END IF;
+  PERFORM enqueue_webhook_deliveries(event_type, jar_id, owner_id, scroll_id);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_scrolljar_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'DELETE') THEN
+    IF OLD.expires_at <= now() THEN
+      PERFORM notify_jar_event('jar.expired', OLD.id, NULL, OLD.user_id);
+    ELSIF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('jar.deleted', OLD.id, NULL, OLD.user_id);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('jar.deleted', NEW.id, NULL);
+  -- Scroll changes touch the jar too, but are reported on their own.
+  ELSIF (OLD.name, OLD.user_id, OLD.access, OLD.password_hash, OLD.tags, OLD.expires_at, OLD.slug)
+      IS DISTINCT FROM (NEW.name, NEW.user_id, NEW.access, NEW.password_hash, NEW.tags, NEW.expires_at, NEW.slug) THEN
+    PERFORM notify_jar_event('jar.updated', NEW.id, NULL);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_scrolljar_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'DELETE') THEN
+    IF OLD.expires_at <= now() THEN
+      PERFORM notify_jar_event('jar.expired', OLD.id, NULL);
+    ELSIF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('jar.deleted', OLD.id, NULL);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('jar.deleted', NEW.id, NULL);
+  ELSIF (OLD.name, OLD.user_id, OLD.access, OLD.password_hash, OLD.tags, OLD.expires_at, OLD.slug)
+      IS DISTINCT FROM (NEW.name, NEW.user_id, NEW.access, NEW.password_hash, NEW.tags, NEW.expires_at, NEW.slug) THEN
+    PERFORM notify_jar_event('jar.updated', NEW.id, NULL);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS notify_jar_event(TEXT, TEXT, TEXT, BIGINT);
+CREATE OR REPLACE FUNCTION notify_jar_event(event_type TEXT, jar_id TEXT, scroll_id TEXT)
+RETURNS VOID AS $$
+BEGIN
+  PERFORM pg_notify('jar_events', json_build_object(
+    'type', event_type,
+    'jar_id', jar_id,
+    'scroll_id', scroll_id
+  )::TEXT);
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS enqueue_webhook_deliveries(TEXT, TEXT, BIGINT, TEXT);
+DROP INDEX IF EXISTS webhook_delivery_pending_idx;
+DROP INDEX IF EXISTS webhook_delivery_webhook_id_idx;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TRIGGER IF EXISTS set_webhook_updated_at ON webhook;
+DROP INDEX IF EXISTS webhook_jar_id_idx;
+DROP INDEX IF EXISTS webhook_user_id_idx;
+DROP TABLE IF EXISTS webhook;
+-- +goose StatementEnd
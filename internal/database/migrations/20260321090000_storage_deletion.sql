@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- An outbox of storage objects to delete, filled in the transaction that
+-- deletes their scrolls and emptied by the cleaner.
+CREATE TABLE IF NOT EXISTS storage_deletion (
+    id BIGSERIAL PRIMARY KEY,
+    key TEXT NOT NULL,
+    -- The objects under key/, the chunks of an appendable scroll, go too.
+    prefix BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS storage_deletion_next_attempt_at_idx ON storage_deletion(next_attempt_at);
+
+-- Runs for scrolls deleted along with their jar too, whether purged from the
+-- trash or expired.
+CREATE OR REPLACE FUNCTION record_scroll_deletion()
+RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO storage_deletion (key, prefix)
+  VALUES (OLD.jar_id || '/' || OLD.id, OLD.appendable);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_scroll_deletion_trigger
+AFTER DELETE ON scroll
+FOR EACH ROW
+EXECUTE FUNCTION record_scroll_deletion();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS record_scroll_deletion_trigger ON scroll;
+DROP FUNCTION IF EXISTS record_scroll_deletion();
+DROP INDEX IF EXISTS storage_deletion_next_attempt_at_idx;
+DROP TABLE IF EXISTS storage_deletion;
+-- +goose StatementEnd
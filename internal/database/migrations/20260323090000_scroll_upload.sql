@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Uploads in progress, one per upload token, so a token can't be used by two
+-- uploads at once. An upload's content is kept at staging_key until it is
+-- promoted to the scroll's own key.
+CREATE TABLE IF NOT EXISTS scroll_upload (
+    jti TEXT PRIMARY KEY,
+    scroll_id CHAR(8) NOT NULL,
+    staging_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS scroll_upload_created_at_idx ON scroll_upload(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS scroll_upload_created_at_idx;
+DROP TABLE IF EXISTS scroll_upload;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A jar's current slug is kept in jar_slug_redirect along with its previous
+-- ones, so the table's primary key alone decides which jar gets a slug.
+INSERT INTO jar_slug_redirect (slug, jar_id)
+SELECT slug, id FROM scrolljar WHERE slug IS NOT NULL
+ON CONFLICT (slug) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM jar_slug_redirect r
+USING scrolljar j
+WHERE r.jar_id = j.id AND r.slug = j.slug;
+-- +goose StatementEnd
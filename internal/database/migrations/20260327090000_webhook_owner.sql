@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Webhooks only hear about jars their user owns, so a jar's webhooks go quiet
+-- once it is transferred to someone else.
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries(event_type TEXT, jar_id TEXT, owner_id BIGINT, scroll_id TEXT)
+RETURNS VOID AS $$
+BEGIN
+  INSERT INTO webhook_delivery (webhook_id, event, payload)
+  SELECT w.id, event_type, jsonb_strip_nulls(jsonb_build_object(
+    'type', event_type,
+    'jar_id', enqueue_webhook_deliveries.jar_id,
+    'scroll_id', scroll_id
+  ))
+  FROM webhook w
+  WHERE w.active
+    AND w.user_id = owner_id
+    AND (w.jar_id = enqueue_webhook_deliveries.jar_id OR w.jar_id IS NULL)
+    AND (cardinality(w.events) = 0 OR event_type = ANY(w.events));
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries(event_type TEXT, jar_id TEXT, owner_id BIGINT, scroll_id TEXT)
+RETURNS VOID AS $$
+BEGIN
+  INSERT INTO webhook_delivery (webhook_id, event, payload)
+  SELECT w.id, event_type, jsonb_strip_nulls(jsonb_build_object(
+    'type', event_type,
+    'jar_id', enqueue_webhook_deliveries.jar_id,
+    'scroll_id', scroll_id
+  ))
+  FROM webhook w
+  WHERE w.active
+    AND (w.jar_id = enqueue_webhook_deliveries.jar_id OR (w.jar_id IS NULL AND w.user_id = owner_id))
+    AND (cardinality(w.events) = 0 OR event_type = ANY(w.events));
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
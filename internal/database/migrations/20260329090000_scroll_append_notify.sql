@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Appends are announced on the jar event channel too, so that a tail hears of
+-- chunks appended through any API server.
+CREATE OR REPLACE FUNCTION notify_scroll_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'INSERT') THEN
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+  ELSIF (TG_OP = 'DELETE') THEN
+    -- Trashed scrolls were reported when they were trashed.
+    IF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    END IF;
+  ELSIF OLD.jar_id <> NEW.jar_id THEN
+    PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+    IF NEW.uploaded THEN
+      PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('scroll.deleted', NEW.jar_id, NEW.id);
+  ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+    PERFORM notify_jar_event('scroll.restored', NEW.jar_id, NEW.id);
+  ELSIF NOT OLD.uploaded AND NEW.uploaded THEN
+    PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+  -- Appends only wake the servers tailing the scroll, and aren't reported.
+  ELSIF NOT NEW.uploaded AND OLD.appended_bytes <> NEW.appended_bytes THEN
+    PERFORM pg_notify('jar_events', json_build_object(
+      'type', 'scroll.appended',
+      'jar_id', NEW.jar_id,
+      'scroll_id', NEW.id
+    )::TEXT);
+  -- The jar's bookkeeping isn't reported.
+  ELSIF NEW.uploaded AND (OLD.title, OLD.format, OLD.filename, OLD.is_readme, OLD.position, OLD.revision)
+      IS DISTINCT FROM (NEW.title, NEW.format, NEW.filename, NEW.is_readme, NEW.position, NEW.revision) THEN
+    PERFORM notify_jar_event('scroll.updated', NEW.jar_id, NEW.id);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_scroll_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF (TG_OP = 'INSERT') THEN
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+  ELSIF (TG_OP = 'DELETE') THEN
+    -- Trashed scrolls were reported when they were trashed.
+    IF OLD.deleted_at IS NULL THEN
+      PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    END IF;
+  ELSIF OLD.jar_id <> NEW.jar_id THEN
+    PERFORM notify_jar_event('scroll.deleted', OLD.jar_id, OLD.id);
+    PERFORM notify_jar_event('scroll.created', NEW.jar_id, NEW.id);
+    IF NEW.uploaded THEN
+      PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+    END IF;
+  ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+    PERFORM notify_jar_event('scroll.deleted', NEW.jar_id, NEW.id);
+  ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+    PERFORM notify_jar_event('scroll.restored', NEW.jar_id, NEW.id);
+  ELSIF NOT OLD.uploaded AND NEW.uploaded THEN
+    PERFORM notify_jar_event('scroll.uploaded', NEW.jar_id, NEW.id);
+  -- Appends and the jar's bookkeeping aren't reported.
+  ELSIF NEW.uploaded AND (OLD.title, OLD.format, OLD.filename, OLD.is_readme, OLD.position, OLD.revision)
+      IS DISTINCT FROM (NEW.title, NEW.format, NEW.filename, NEW.is_readme, NEW.position, NEW.revision) THEN
+    PERFORM notify_jar_event('scroll.updated', NEW.jar_id, NEW.id);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
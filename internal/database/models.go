@@ -8,16 +8,101 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Scroll struct {
-	ID        string
+type Job struct {
+	ID          int64
+	Kind        string
+	Payload     []byte
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamptz
+	LastError   pgtype.Text
+	CreatedAt   pgtype.Timestamptz
+}
+
+type JarMember struct {
+	JarID     string
+	UserID    int64
+	Role      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type JarShareLink struct {
+	ID        int64
 	JarID     string
-	Title     pgtype.Text
-	Format    pgtype.Text
-	Uploaded  bool
+	TokenHash []byte
+	ExpiresAt pgtype.Timestamptz
+	MaxUses   pgtype.Int4
+	Uses      int32
+	RevokedAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type JarSlugRedirect struct {
+	Slug      string
+	JarID     string
+	CreatedAt pgtype.Timestamptz
+}
+
+type JarTransfer struct {
+	ID         int64
+	JarID      string
+	FromUserID int64
+	ToUserID   int64
+	ExpiresAt  pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+}
+
+type JarUnlockAttempt struct {
+	JarID        string
+	FailedCount  int32
+	LockedUntil  pgtype.Timestamptz
+	LastFailedAt pgtype.Timestamptz
+}
+
+type Scroll struct {
+	ID               string
+	JarID            string
+	Title            pgtype.Text
+	Format           pgtype.Text
+	Uploaded         bool
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+	DeletedAt        pgtype.Timestamptz
+	Position         int32
+	Filename         pgtype.Text
+	FormatConfidence pgtype.Float4
+	IsReadme         bool
+	Revision         int32
+	Appendable       bool
+	AppendedBytes    int64
+}
+
+type ScrollComment struct {
+	ID        int64
+	ScrollID  string
+	UserID    pgtype.Int8
+	Revision  int32
+	LineStart int32
+	LineEnd   int32
+	Body      string
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
 
+type ScrollContent struct {
+	ScrollID string
+	Body     string
+	BodyTsv  interface{}
+}
+
+type ScrollUpload struct {
+	Jti        string
+	ScrollID   string
+	StagingKey string
+	CreatedAt  pgtype.Timestamptz
+}
+
 type Scrolljar struct {
 	ID           string
 	Name         pgtype.Text
@@ -28,6 +113,19 @@ type Scrolljar struct {
 	ExpiresAt    pgtype.Timestamptz
 	CreatedAt    pgtype.Timestamptz
 	UpdatedAt    pgtype.Timestamptz
+	DeletedAt    pgtype.Timestamptz
+	Slug         pgtype.Text
+	ForkedFrom   pgtype.Text
+}
+
+type StorageDeletion struct {
+	ID            int64
+	Key           string
+	Prefix        bool
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	LastError     pgtype.Text
+	CreatedAt     pgtype.Timestamptz
 }
 
 type Token struct {
@@ -46,3 +144,30 @@ type UserAccount struct {
 	CreatedAt    pgtype.Timestamptz
 	UpdatedAt    pgtype.Timestamptz
 }
+
+type Webhook struct {
+	ID        int64
+	UserID    int64
+	JarID     pgtype.Text
+	Url       string
+	Secret    string
+	Events    []string
+	Active    bool
+	Failures  int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	ResponseStatus pgtype.Int4
+	LastError      pgtype.Text
+	CreatedAt      pgtype.Timestamptz
+	DeliveredAt    pgtype.Timestamptz
+}
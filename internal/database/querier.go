@@ -11,28 +11,142 @@ import (
 )
 
 type Querier interface {
-	DeleteExpiredJars(ctx context.Context) error
-	DeleteExpiredTokens(ctx context.Context) error
-	DeleteJar(ctx context.Context, id string) error
-	DeleteScroll(ctx context.Context, id string) error
+	// Makes room for a chunk at the end of an appendable scroll that hasn't been
+	// sealed, returning the offset the chunk starts at.
+	AppendScroll(ctx context.Context, arg AppendScrollParams) (int64, error)
+	// Leases due jobs of the given kinds to a worker. A job whose worker died is
+	// run again once the lease runs out.
+	ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]ClaimJobsRow, error)
+	// Leases due deletions to a cleaner. A deletion whose cleaner died is retried
+	// once the lease runs out.
+	ClaimStorageDeletions(ctx context.Context, limit int32) ([]ClaimStorageDeletionsRow, error)
+	// Leases due deliveries of active webhooks to a worker. A delivery whose
+	// worker died is retried once the lease runs out.
+	ClaimWebhookDeliveries(ctx context.Context, limit int32) ([]ClaimWebhookDeliveriesRow, error)
+	// Unflags the README of the scroll's jar, unless it is the scroll itself.
+	ClearJarReadme(ctx context.Context, id string) error
+	CopyScrollContent(ctx context.Context, arg CopyScrollContentParams) error
+	CountJarScrolls(ctx context.Context, jarID string) (int64, error)
+	// Counts an attempt before its password is checked, returning no row while
+	// the jar is locked. Attempts older than a day are forgotten first.
+	CountJarUnlockAttempt(ctx context.Context, jarID string) (int32, error)
+	DeleteExpiredJars(ctx context.Context) (int64, error)
+	DeleteExpiredTokens(ctx context.Context) (int64, error)
+	DeleteJarMember(ctx context.Context, arg DeleteJarMemberParams) (int64, error)
+	DeleteJarTransfer(ctx context.Context, arg DeleteJarTransferParams) (int64, error)
+	DeleteJob(ctx context.Context, id int64) error
+	DeleteScrollComment(ctx context.Context, id int64) (int64, error)
+	DeleteSlugRedirect(ctx context.Context, arg DeleteSlugRedirectParams) error
+	DeleteStorageDeletions(ctx context.Context, dollar_1 []int64) error
 	DeleteTokenByHash(ctx context.Context, tokenHash []byte) error
 	DeleteUserTokens(ctx context.Context, userID int64) error
-	GetExistingScrollIDs(ctx context.Context, dollar_1 []string) ([]string, error)
+	DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) (int64, error)
+	GetActivatedUsersByUsername(ctx context.Context, username string) ([]UserAccount, error)
+	// Checks a link without using it up, for streams opened with it.
+	GetActiveShareLinkID(ctx context.Context, arg GetActiveShareLinkIDParams) (int64, error)
+	// Keys are a jar ID and a scroll ID, the key a scroll's content is stored
+	// under. Only keys of scrolls that are still in that jar are returned, along
+	// with whether they are appendable scrolls whose chunks are still in use.
+	GetExistingScrollKeys(ctx context.Context, dollar_1 []string) ([]GetExistingScrollKeysRow, error)
+	// Jars are found by ID, by slug, or by a slug they used to have.
 	GetJar(ctx context.Context, id string) (Scrolljar, error)
+	GetJarForkCount(ctx context.Context, forkedFrom pgtype.Text) (int64, error)
+	GetJarMemberRole(ctx context.Context, arg GetJarMemberRoleParams) (string, error)
+	GetJarMembers(ctx context.Context, jarID string) ([]GetJarMembersRow, error)
 	GetJarOwnerID(ctx context.Context, id string) (pgtype.Int8, error)
+	GetJarReadme(ctx context.Context, jarID string) (Scroll, error)
+	GetJarTransferForUpdate(ctx context.Context, arg GetJarTransferForUpdateParams) (JarTransfer, error)
+	// Pending transfers the user either started or was offered.
+	GetJarTransfersByUser(ctx context.Context, userID int64) ([]GetJarTransfersByUserRow, error)
 	GetJarsByUser(ctx context.Context, userID pgtype.Int8) ([]Scrolljar, error)
 	GetScroll(ctx context.Context, id string) (Scroll, error)
+	GetScrollComment(ctx context.Context, arg GetScrollCommentParams) (ScrollComment, error)
+	GetScrollComments(ctx context.Context, scrollID string) ([]GetScrollCommentsRow, error)
 	GetScrollsByJar(ctx context.Context, jarID string) ([]Scroll, error)
+	GetShareLinksByJar(ctx context.Context, jarID string) ([]JarShareLink, error)
 	GetTokenByHash(ctx context.Context, tokenHash []byte) (GetTokenByHashRow, error)
+	GetTrashedJarForUpdate(ctx context.Context, arg GetTrashedJarForUpdateParams) (Scrolljar, error)
+	GetTrashedJarsByUser(ctx context.Context, arg GetTrashedJarsByUserParams) ([]Scrolljar, error)
+	// Scrolls of a trashed jar are listed through the jar instead.
+	GetTrashedScrollsByUser(ctx context.Context, arg GetTrashedScrollsByUserParams) ([]Scroll, error)
 	GetUserByEmail(ctx context.Context, email string) (UserAccount, error)
 	GetUserByID(ctx context.Context, id int64) (UserAccount, error)
+	GetWebhook(ctx context.Context, arg GetWebhookParams) (Webhook, error)
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	GetWebhooksByUser(ctx context.Context, userID int64) ([]Webhook, error)
+	// A webhook that keeps failing is disabled until its owner turns it back on.
+	IncrementWebhookFailures(ctx context.Context, arg IncrementWebhookFailuresParams) error
 	InsertJar(ctx context.Context, arg InsertJarParams) (Scrolljar, error)
+	InsertJob(ctx context.Context, arg InsertJobParams) (int64, error)
+	// Queues the object a moved scroll left behind in its old jar for deletion.
+	// A deletion still queued for its new key, from moving it out of that jar
+	// before, is dropped so that it doesn't take the scroll's content with it.
+	InsertMovedScrollDeletion(ctx context.Context, arg InsertMovedScrollDeletionParams) error
+	// New scrolls go after every other scroll in the jar.
 	InsertScroll(ctx context.Context, arg InsertScrollParams) (Scroll, error)
+	InsertScrollComment(ctx context.Context, arg InsertScrollCommentParams) (ScrollComment, error)
+	// Nothing is inserted if the token is already being used.
+	InsertScrollUpload(ctx context.Context, arg InsertScrollUploadParams) (int64, error)
+	// Queues the chunks of a sealed appendable scroll for deletion. The key ends in
+	// a slash, which leaves the scroll's own object alone.
+	InsertSegmentDeletion(ctx context.Context, id string) error
+	InsertShareLink(ctx context.Context, arg InsertShareLinkParams) (JarShareLink, error)
+	InsertSlugRedirect(ctx context.Context, arg InsertSlugRedirectParams) error
 	InsertUser(ctx context.Context, arg InsertUserParams) (UserAccount, error)
+	InsertWebhook(ctx context.Context, arg InsertWebhookParams) (Webhook, error)
+	// Holds off appends to a scroll that hasn't been sealed until the end of the
+	// transaction.
+	LockAppendableScroll(ctx context.Context, id string) (LockAppendableScrollRow, error)
+	// A moved scroll stops being a README, since its new jar may have one.
+	MoveScroll(ctx context.Context, arg MoveScrollParams) (pgtype.Timestamptz, error)
+	// Forgets uploads whose tokens have long expired, queueing whatever staged
+	// content they left behind for deletion.
+	PurgeScrollUploads(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	PurgeTrashedJars(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	PurgeTrashedScrolls(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	// Scrolls that were never uploaded are abandoned once their upload tokens
+	// have expired. Appendable scrolls count while nothing was appended.
+	PurgeUnuploadedScrolls(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	// Frees a token whose upload failed for another try, queueing its staged
+	// content for deletion.
+	ReleaseScrollUpload(ctx context.Context, jti string) error
+	ResetJarUnlockFailures(ctx context.Context, jarID string) error
+	ResetWebhookFailures(ctx context.Context, id int64) error
+	RestoreJar(ctx context.Context, id string) error
+	// Brings back the scrolls that were trashed together with the jar.
+	RestoreJarScrolls(ctx context.Context, arg RestoreJarScrollsParams) error
+	RestoreScroll(ctx context.Context, arg RestoreScrollParams) (int64, error)
+	RevokeShareLink(ctx context.Context, arg RevokeShareLinkParams) (int64, error)
+	SearchScrolls(ctx context.Context, arg SearchScrollsParams) ([]SearchScrollsRow, error)
+	SetJarLockedUntil(ctx context.Context, arg SetJarLockedUntilParams) error
+	SetJarOwner(ctx context.Context, arg SetJarOwnerParams) (int64, error)
+	SetJarSlug(ctx context.Context, arg SetJarSlugParams) (Scrolljar, error)
+	// A job that is out of attempts, or can't succeed, is dead, and is otherwise
+	// run again at run_at.
+	SetJobFailed(ctx context.Context, arg SetJobFailedParams) error
+	SetScrollFormatGuess(ctx context.Context, arg SetScrollFormatGuessParams) (pgtype.Timestamptz, error)
+	// Each scroll is placed at its index in the given list of IDs.
+	SetScrollPositions(ctx context.Context, arg SetScrollPositionsParams) (int64, error)
 	SetScrollUploaded(ctx context.Context, arg SetScrollUploadedParams) (pgtype.Timestamptz, error)
+	SetScrollsUploaded(ctx context.Context, dollar_1 []string) error
+	SetStorageDeletionFailed(ctx context.Context, arg SetStorageDeletionFailedParams) error
+	SetWebhookDelivered(ctx context.Context, arg SetWebhookDeliveredParams) error
+	// A delivery that is out of attempts fails for good, and is otherwise retried
+	// at next_attempt_at.
+	SetWebhookDeliveryFailed(ctx context.Context, arg SetWebhookDeliveryFailedParams) error
+	TouchJar(ctx context.Context, arg TouchJarParams) (pgtype.Timestamptz, error)
+	TrashJar(ctx context.Context, id string) (int64, error)
+	TrashScroll(ctx context.Context, id string) (int64, error)
 	UpdateScroll(ctx context.Context, arg UpdateScrollParams) (pgtype.Timestamptz, error)
+	UpdateScrollCommentBody(ctx context.Context, arg UpdateScrollCommentBodyParams) (ScrollComment, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (pgtype.Timestamptz, error)
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
+	UpsertJarMember(ctx context.Context, arg UpsertJarMemberParams) (JarMember, error)
+	// A jar has at most one pending transfer; starting a new one replaces it.
+	UpsertJarTransfer(ctx context.Context, arg UpsertJarTransferParams) (JarTransfer, error)
+	UpsertScrollContent(ctx context.Context, arg UpsertScrollContentParams) error
 	UpsertToken(ctx context.Context, arg UpsertTokenParams) error
+	UseShareLink(ctx context.Context, arg UseShareLinkParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,25 @@
+-- name: InsertScrollComment :one
+INSERT INTO scroll_comment (scroll_id, user_id, revision, line_start, line_end, body)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetScrollComments :many
+SELECT c.id, c.scroll_id, c.user_id, c.revision, c.line_start, c.line_end, c.body, c.created_at, c.updated_at, u.username
+FROM scroll_comment c
+LEFT JOIN user_account u ON u.id = c.user_id
+WHERE c.scroll_id = $1
+ORDER BY c.line_start, c.id;
+
+-- name: GetScrollComment :one
+SELECT id, scroll_id, user_id, revision, line_start, line_end, body, created_at, updated_at
+FROM scroll_comment
+WHERE id = $1 AND scroll_id = $2;
+
+-- name: UpdateScrollCommentBody :one
+UPDATE scroll_comment
+SET body = $1
+WHERE id = $2
+RETURNING *;
+
+-- name: DeleteScrollComment :execrows
+DELETE FROM scroll_comment WHERE id = $1;
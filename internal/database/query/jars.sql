@@ -1,24 +1,66 @@
 -- name: GetJar :one
-SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at
+-- Jars are found by ID, by slug, or by a slug they used to have.
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
 FROM scrolljar
-WHERE id = $1 AND (expires_at IS NULL OR expires_at > now());
+WHERE (id = $1 OR slug = $1 OR id = (SELECT jar_id FROM jar_slug_redirect WHERE jar_slug_redirect.slug = $1))
+    AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now());
 
 -- name: GetJarOwnerID :one
 SELECT user_id FROM scrolljar
-WHERE id = $1 AND (expires_at IS NULL OR expires_at > now());
+WHERE id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now());
 
 -- name: GetJarsByUser :many
-SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
 FROM scrolljar
-WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > now());
+WHERE user_id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now());
 
 -- name: InsertJar :one
-INSERT INTO scrolljar (id, user_id, name, access, password_hash, tags, expires_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO scrolljar (id, user_id, name, access, password_hash, tags, expires_at, forked_from)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
--- name: DeleteJar :exec
-DELETE FROM scrolljar WHERE id = $1;
+-- name: GetJarForkCount :one
+SELECT COUNT(*) FROM scrolljar
+WHERE forked_from = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now());
 
--- name: DeleteExpiredJars :exec
+-- name: TouchJar :one
+UPDATE scrolljar SET updated_at = now()
+WHERE id = $1 AND updated_at = $2
+RETURNING updated_at;
+
+-- name: TrashJar :execrows
+UPDATE scrolljar SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetTrashedJarsByUser :many
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
+FROM scrolljar
+WHERE user_id = sqlc.arg(user_id) AND deleted_at > sqlc.arg(deleted_after)
+    AND (expires_at IS NULL OR expires_at > now())
+ORDER BY deleted_at DESC, id;
+
+-- name: GetTrashedJarForUpdate :one
+SELECT id, name, user_id, access, password_hash, tags, expires_at, created_at, updated_at, deleted_at, slug, forked_from
+FROM scrolljar
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) AND deleted_at > sqlc.arg(deleted_after)
+    AND (expires_at IS NULL OR expires_at > now())
+FOR UPDATE;
+
+-- name: RestoreJar :exec
+UPDATE scrolljar SET deleted_at = NULL WHERE id = $1;
+
+-- name: PurgeTrashedJars :execrows
+DELETE FROM scrolljar WHERE deleted_at <= $1;
+
+-- name: SetJarSlug :one
+UPDATE scrolljar SET slug = $2 WHERE id = $1
+RETURNING *;
+
+-- name: InsertSlugRedirect :exec
+INSERT INTO jar_slug_redirect (slug, jar_id) VALUES ($1, $2);
+
+-- name: DeleteSlugRedirect :exec
+DELETE FROM jar_slug_redirect WHERE slug = $1 AND jar_id = $2;
+
+-- name: DeleteExpiredJars :execrows
 DELETE FROM scrolljar WHERE expires_at <= now();
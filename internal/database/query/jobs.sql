@@ -0,0 +1,30 @@
+-- name: InsertJob :one
+INSERT INTO job (kind, payload, max_attempts)
+VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: ClaimJobs :many
+-- Leases due jobs of the given kinds to a worker. A job whose worker died is
+-- run again once the lease runs out.
+UPDATE job
+SET attempts = attempts + 1, run_at = now() + sqlc.arg(lease)::interval
+WHERE id IN (
+    SELECT id FROM job
+    WHERE status = 'pending' AND run_at <= now() AND kind = ANY(sqlc.arg(kinds)::text[])
+    ORDER BY run_at
+    LIMIT sqlc.arg(max_jobs)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, payload, attempts, max_attempts;
+
+-- name: DeleteJob :exec
+DELETE FROM job WHERE id = $1;
+
+-- name: SetJobFailed :exec
+-- A job that is out of attempts, or can't succeed, is dead, and is otherwise
+-- run again at run_at.
+UPDATE job
+SET status = CASE WHEN sqlc.arg(dead)::bool OR attempts >= max_attempts THEN 'dead' ELSE 'pending' END,
+    run_at = sqlc.arg(run_at),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
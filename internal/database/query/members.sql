@@ -0,0 +1,19 @@
+-- name: UpsertJarMember :one
+INSERT INTO jar_member (jar_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (jar_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: GetJarMemberRole :one
+SELECT role FROM jar_member
+WHERE jar_id = $1 AND user_id = $2;
+
+-- name: GetJarMembers :many
+SELECT m.jar_id, m.user_id, m.role, m.created_at, u.username
+FROM jar_member m
+JOIN user_account u ON u.id = m.user_id
+WHERE m.jar_id = $1
+ORDER BY m.created_at, m.user_id;
+
+-- name: DeleteJarMember :execrows
+DELETE FROM jar_member WHERE jar_id = $1 AND user_id = $2;
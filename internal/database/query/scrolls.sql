@@ -1,34 +1,130 @@
 -- name: InsertScroll :one
-INSERT INTO scroll (id, jar_id, title, format)
-VALUES ($1, $2, $3, $4)
+-- New scrolls go after every other scroll in the jar.
+INSERT INTO scroll (id, jar_id, title, format, format_confidence, filename, is_readme, appendable, appended_bytes, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (SELECT COALESCE(MAX(position) + 1, 0) FROM scroll WHERE jar_id = $2))
 RETURNING *;
 
 -- name: GetScroll :one
-SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
 FROM scroll s
 JOIN scrolljar j ON j.id = s.jar_id
-WHERE s.id = $1 AND (j.expires_at IS NULL OR j.expires_at > now());
+WHERE s.id = $1 AND s.deleted_at IS NULL AND j.deleted_at IS NULL
+    AND (j.expires_at IS NULL OR j.expires_at > now());
 
 -- name: GetScrollsByJar :many
-SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
 FROM scroll s
 JOIN scrolljar j ON j.id = s.jar_id
-WHERE s.jar_id = $1 AND s.uploaded = TRUE AND (j.expires_at IS NULL OR j.expires_at > now());
+WHERE s.jar_id = $1 AND s.uploaded = TRUE AND s.deleted_at IS NULL AND j.deleted_at IS NULL
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+ORDER BY s.position, s.id;
 
 -- name: UpdateScroll :one
 UPDATE scroll
-SET title = $1, format = $2
-WHERE id = $3 AND updated_at = $4
+SET title = $1, format = $2, format_confidence = $3, filename = $4, is_readme = $5
+WHERE id = $6 AND updated_at = $7
 RETURNING updated_at;
 
+-- name: ClearJarReadme :exec
+-- Unflags the README of the scroll's jar, unless it is the scroll itself.
+UPDATE scroll
+SET is_readme = FALSE
+WHERE jar_id = (SELECT jar_id FROM scroll WHERE scroll.id = $1) AND id <> $1 AND is_readme;
+
+-- name: GetJarReadme :one
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
+FROM scroll s
+WHERE s.jar_id = $1 AND s.is_readme AND s.uploaded = TRUE AND s.deleted_at IS NULL;
+
 -- name: SetScrollUploaded :one
 UPDATE scroll
-SET uploaded = TRUE
+SET uploaded = TRUE, revision = revision + 1
 WHERE id = $1 AND updated_at = $2
 RETURNING updated_at;
 
--- name: DeleteScroll :exec
-DELETE FROM scroll WHERE id = $1;
+-- name: SetScrollFormatGuess :one
+UPDATE scroll
+SET format = $1, format_confidence = $2
+WHERE id = $3
+RETURNING updated_at;
+
+-- name: AppendScroll :one
+-- Makes room for a chunk at the end of an appendable scroll that hasn't been
+-- sealed, returning the offset the chunk starts at.
+UPDATE scroll
+SET appended_bytes = appended_bytes + sqlc.arg(size)
+WHERE id = sqlc.arg(id) AND appendable AND NOT uploaded AND deleted_at IS NULL
+    AND appended_bytes + sqlc.arg(size) <= sqlc.arg(max_size)
+RETURNING (appended_bytes - sqlc.arg(size))::BIGINT AS start;
+
+-- name: LockAppendableScroll :one
+-- Holds off appends to a scroll that hasn't been sealed until the end of the
+-- transaction.
+SELECT appended_bytes, updated_at FROM scroll
+WHERE id = $1 AND appendable AND NOT uploaded AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: TrashScroll :execrows
+UPDATE scroll SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetTrashedScrollsByUser :many
+-- Scrolls of a trashed jar are listed through the jar instead.
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
+FROM scroll s
+JOIN scrolljar j ON j.id = s.jar_id
+WHERE j.user_id = sqlc.arg(user_id) AND j.deleted_at IS NULL
+    AND s.deleted_at > sqlc.arg(deleted_after)
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+ORDER BY s.deleted_at DESC, s.id;
+
+-- name: RestoreScroll :execrows
+UPDATE scroll s SET deleted_at = NULL
+FROM scrolljar j
+WHERE s.id = sqlc.arg(id) AND j.id = s.jar_id
+    AND j.user_id = sqlc.arg(user_id) AND j.deleted_at IS NULL
+    AND s.deleted_at > sqlc.arg(deleted_after)
+    AND (j.expires_at IS NULL OR j.expires_at > now());
+
+-- name: RestoreJarScrolls :exec
+-- Brings back the scrolls that were trashed together with the jar.
+UPDATE scroll SET deleted_at = NULL
+WHERE jar_id = $1 AND deleted_at = $2;
+
+-- name: PurgeTrashedScrolls :execrows
+DELETE FROM scroll WHERE deleted_at <= $1;
+
+-- name: PurgeUnuploadedScrolls :execrows
+-- Scrolls that were never uploaded are abandoned once their upload tokens
+-- have expired. Appendable scrolls count while nothing was appended.
+DELETE FROM scroll
+WHERE NOT uploaded AND appended_bytes = 0 AND created_at <= $1;
+
+-- name: MoveScroll :one
+-- A moved scroll stops being a README, since its new jar may have one.
+UPDATE scroll
+SET jar_id = $1, is_readme = FALSE, position = (SELECT COALESCE(MAX(position) + 1, 0) FROM scroll WHERE jar_id = $1)
+WHERE id = $2 AND updated_at = $3 AND deleted_at IS NULL
+RETURNING updated_at;
+
+-- name: SetScrollsUploaded :exec
+UPDATE scroll SET uploaded = TRUE, revision = revision + 1 WHERE id = ANY($1::TEXT[]);
 
--- name: GetExistingScrollIDs :many
-SELECT id FROM scroll WHERE id = ANY($1::TEXT[]);
+-- name: CountJarScrolls :one
+SELECT COUNT(*) FROM scroll WHERE jar_id = $1 AND uploaded = TRUE AND deleted_at IS NULL;
+
+-- name: SetScrollPositions :execrows
+-- Each scroll is placed at its index in the given list of IDs.
+UPDATE scroll s
+SET position = (o.position - 1)::INT
+FROM unnest(sqlc.arg(ids)::TEXT[]) WITH ORDINALITY AS o(id, position)
+WHERE s.id = o.id AND s.jar_id = sqlc.arg(jar_id) AND s.uploaded = TRUE AND s.deleted_at IS NULL;
+
+-- name: GetExistingScrollKeys :many
+-- Keys are a jar ID and a scroll ID, the key a scroll's content is stored
+-- under. Only keys of scrolls that are still in that jar are returned, along
+-- with whether they are appendable scrolls whose chunks are still in use.
+SELECT (s.jar_id || '/' || s.id)::TEXT AS key, (s.appendable AND NOT s.uploaded)::BOOLEAN AS unsealed
+FROM scroll s
+JOIN unnest($1::TEXT[]) AS k(key)
+    ON s.id = split_part(k.key, '/', 2) AND s.jar_id = split_part(k.key, '/', 1);
@@ -0,0 +1,29 @@
+-- name: UpsertScrollContent :exec
+INSERT INTO scroll_content (scroll_id, body)
+VALUES ($1, $2)
+ON CONFLICT (scroll_id) DO UPDATE SET body = EXCLUDED.body;
+
+-- name: CopyScrollContent :exec
+INSERT INTO scroll_content (scroll_id, body)
+SELECT sqlc.arg(to_scroll_id)::TEXT, body FROM scroll_content WHERE scroll_id = sqlc.arg(from_scroll_id);
+
+-- name: SearchScrolls :many
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at,
+    ts_rank(c.body_tsv, query)::REAL AS rank,
+    ts_headline(
+        'simple', c.body, query,
+        'MaxFragments=2, MaxWords=24, MinWords=8, StartSel=' || chr(2) || ', StopSel=' || chr(3)
+    )::TEXT AS snippet,
+    COUNT(*) OVER () AS total
+FROM scroll_content c
+JOIN scroll s ON s.id = c.scroll_id
+JOIN scrolljar j ON j.id = s.jar_id,
+    websearch_to_tsquery('simple', sqlc.arg(query)::TEXT) query
+WHERE c.body_tsv @@ query
+    AND s.uploaded = TRUE
+    AND s.deleted_at IS NULL AND j.deleted_at IS NULL
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+    AND (j.access = 0 OR j.user_id = sqlc.narg(user_id)::BIGINT
+        OR EXISTS (SELECT 1 FROM jar_member m WHERE m.jar_id = j.id AND m.user_id = sqlc.narg(user_id)::BIGINT))
+ORDER BY rank DESC, s.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
@@ -0,0 +1,31 @@
+-- name: InsertShareLink :one
+INSERT INTO jar_share_link (jar_id, token_hash, expires_at, max_uses)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetShareLinksByJar :many
+SELECT id, jar_id, token_hash, expires_at, max_uses, uses, revoked_at, created_at
+FROM jar_share_link
+WHERE jar_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeShareLink :execrows
+UPDATE jar_share_link
+SET revoked_at = now()
+WHERE id = $1 AND jar_id = $2 AND revoked_at IS NULL;
+
+-- name: UseShareLink :one
+UPDATE jar_share_link
+SET uses = uses + 1
+WHERE token_hash = $1 AND jar_id = $2
+    AND revoked_at IS NULL
+    AND expires_at > now()
+    AND (max_uses IS NULL OR uses < max_uses)
+RETURNING id;
+
+-- name: GetActiveShareLinkID :one
+-- Checks a link without using it up, for streams opened with it.
+SELECT id FROM jar_share_link
+WHERE token_hash = $1 AND jar_id = $2
+    AND revoked_at IS NULL
+    AND expires_at > now();
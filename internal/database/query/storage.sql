@@ -0,0 +1,38 @@
+-- name: ClaimStorageDeletions :many
+-- Leases due deletions to a cleaner. A deletion whose cleaner died is retried
+-- once the lease runs out.
+UPDATE storage_deletion
+SET attempts = attempts + 1, next_attempt_at = now() + interval '5 minutes'
+WHERE id IN (
+    SELECT id FROM storage_deletion
+    WHERE next_attempt_at <= now()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, key, prefix, attempts;
+
+-- name: DeleteStorageDeletions :exec
+DELETE FROM storage_deletion WHERE id = ANY($1::BIGINT[]);
+
+-- name: InsertMovedScrollDeletion :exec
+-- Queues the object a moved scroll left behind in its old jar for deletion.
+-- A deletion still queued for its new key, from moving it out of that jar
+-- before, is dropped so that it doesn't take the scroll's content with it.
+WITH dropped AS (
+    DELETE FROM storage_deletion WHERE key = sqlc.arg(new_key) AND NOT prefix
+)
+INSERT INTO storage_deletion (key) VALUES (sqlc.arg(old_key));
+
+-- name: InsertSegmentDeletion :exec
+-- Queues the chunks of a sealed appendable scroll for deletion. The key ends in
+-- a slash, which leaves the scroll's own object alone.
+INSERT INTO storage_deletion (key, prefix)
+SELECT jar_id || '/' || id || '/', TRUE
+FROM scroll
+WHERE id = $1;
+
+-- name: SetStorageDeletionFailed :exec
+UPDATE storage_deletion
+SET next_attempt_at = $1, last_error = $2
+WHERE id = $3;
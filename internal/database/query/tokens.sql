@@ -17,5 +17,5 @@ DELETE FROM token WHERE token_hash = $1;
 -- name: DeleteUserTokens :exec
 DELETE FROM token WHERE user_id = $1;
 
--- name: DeleteExpiredTokens :exec
+-- name: DeleteExpiredTokens :execrows
 DELETE FROM token WHERE expires_at <= now();
@@ -0,0 +1,39 @@
+-- name: UpsertJarTransfer :one
+-- A jar has at most one pending transfer; starting a new one replaces it.
+INSERT INTO jar_transfer (jar_id, from_user_id, to_user_id, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (jar_id) DO UPDATE SET
+    id = DEFAULT,
+    from_user_id = EXCLUDED.from_user_id,
+    to_user_id = EXCLUDED.to_user_id,
+    expires_at = EXCLUDED.expires_at,
+    created_at = now()
+RETURNING *;
+
+-- name: GetJarTransfersByUser :many
+-- Pending transfers the user either started or was offered.
+SELECT t.id, t.jar_id, t.from_user_id, t.to_user_id, t.expires_at, t.created_at,
+    j.name AS jar_name, f.username AS from_username, u.username AS to_username
+FROM jar_transfer t
+JOIN scrolljar j ON j.id = t.jar_id
+JOIN user_account f ON f.id = t.from_user_id
+JOIN user_account u ON u.id = t.to_user_id
+WHERE (t.from_user_id = sqlc.arg(user_id) OR t.to_user_id = sqlc.arg(user_id))
+    AND t.expires_at > now()
+    AND j.deleted_at IS NULL AND (j.expires_at IS NULL OR j.expires_at > now())
+ORDER BY t.created_at DESC, t.id DESC;
+
+-- name: GetJarTransferForUpdate :one
+SELECT id, jar_id, from_user_id, to_user_id, expires_at, created_at
+FROM jar_transfer
+WHERE id = $1 AND to_user_id = $2 AND expires_at > now()
+FOR UPDATE;
+
+-- name: SetJarOwner :execrows
+UPDATE scrolljar SET user_id = sqlc.arg(to_user_id)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(from_user_id)
+    AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now());
+
+-- name: DeleteJarTransfer :execrows
+DELETE FROM jar_transfer
+WHERE id = sqlc.arg(id) AND (from_user_id = sqlc.arg(user_id) OR to_user_id = sqlc.arg(user_id));
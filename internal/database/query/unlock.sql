@@ -0,0 +1,19 @@
+-- name: CountJarUnlockAttempt :one
+-- Counts an attempt before its password is checked, returning no row while
+-- the jar is locked. Attempts older than a day are forgotten first.
+INSERT INTO jar_unlock_attempt (jar_id, failed_count, last_failed_at)
+VALUES ($1, 1, now())
+ON CONFLICT (jar_id) DO UPDATE SET
+    failed_count = CASE
+        WHEN jar_unlock_attempt.last_failed_at < now() - INTERVAL '1 day' THEN 1
+        ELSE jar_unlock_attempt.failed_count + 1
+    END,
+    last_failed_at = now()
+WHERE jar_unlock_attempt.locked_until IS NULL OR jar_unlock_attempt.locked_until <= now()
+RETURNING failed_count;
+
+-- name: SetJarLockedUntil :exec
+UPDATE jar_unlock_attempt SET locked_until = $2 WHERE jar_id = $1;
+
+-- name: ResetJarUnlockFailures :exec
+DELETE FROM jar_unlock_attempt WHERE jar_id = $1;
@@ -0,0 +1,25 @@
+-- name: InsertScrollUpload :execrows
+-- Nothing is inserted if the token is already being used.
+INSERT INTO scroll_upload (jti, scroll_id, staging_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING;
+
+-- name: ReleaseScrollUpload :exec
+-- Frees a token whose upload failed for another try, queueing its staged
+-- content for deletion.
+WITH released AS (
+    DELETE FROM scroll_upload WHERE jti = $1
+    RETURNING staging_key
+)
+INSERT INTO storage_deletion (key)
+SELECT staging_key FROM released;
+
+-- name: PurgeScrollUploads :execrows
+-- Forgets uploads whose tokens have long expired, queueing whatever staged
+-- content they left behind for deletion.
+WITH purged AS (
+    DELETE FROM scroll_upload WHERE created_at <= $1
+    RETURNING staging_key
+)
+INSERT INTO storage_deletion (key)
+SELECT staging_key FROM purged;
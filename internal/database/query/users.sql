@@ -18,3 +18,9 @@ UPDATE user_account
 SET username = $1, email = $2, activated = $3, password_hash = $4
 WHERE id = $5 AND updated_at = $6
 RETURNING updated_at;
+
+-- name: GetActivatedUsersByUsername :many
+SELECT id, username, email, password_hash, activated, created_at, updated_at
+FROM user_account
+WHERE username = $1 AND activated = TRUE
+LIMIT 2;
@@ -0,0 +1,67 @@
+-- name: InsertWebhook :one
+INSERT INTO webhook (user_id, jar_id, url, secret, events)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhook WHERE id = $1 AND user_id = $2;
+
+-- name: GetWebhooksByUser :many
+SELECT * FROM webhook
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateWebhook :one
+UPDATE webhook
+SET url = $1, secret = $2, events = $3, active = $4, failures = $5
+WHERE id = $6 AND user_id = $7
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhook WHERE id = $1 AND user_id = $2;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM webhook_delivery
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: ClaimWebhookDeliveries :many
+-- Leases due deliveries of active webhooks to a worker. A delivery whose
+-- worker died is retried once the lease runs out.
+UPDATE webhook_delivery d
+SET attempts = d.attempts + 1, next_attempt_at = now() + interval '5 minutes'
+FROM webhook w
+WHERE w.id = d.webhook_id AND d.id IN (
+    SELECT dd.id FROM webhook_delivery dd
+    JOIN webhook ww ON ww.id = dd.webhook_id
+    WHERE dd.status = 'pending' AND dd.next_attempt_at <= now() AND ww.active
+    ORDER BY dd.next_attempt_at
+    LIMIT $1
+    FOR UPDATE OF dd SKIP LOCKED
+)
+RETURNING d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret;
+
+-- name: SetWebhookDelivered :exec
+UPDATE webhook_delivery
+SET status = 'delivered', response_status = $1, last_error = NULL, delivered_at = now()
+WHERE id = $2;
+
+-- name: SetWebhookDeliveryFailed :exec
+-- A delivery that is out of attempts fails for good, and is otherwise retried
+-- at next_attempt_at.
+UPDATE webhook_delivery
+SET status = CASE WHEN attempts >= sqlc.arg(max_attempts) THEN 'failed' ELSE 'pending' END,
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    response_status = sqlc.arg(response_status),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: ResetWebhookFailures :exec
+UPDATE webhook SET failures = 0 WHERE id = $1 AND failures > 0;
+
+-- name: IncrementWebhookFailures :exec
+-- A webhook that keeps failing is disabled until its owner turns it back on.
+UPDATE webhook
+SET failures = failures + 1, active = active AND failures + 1 < sqlc.arg(disable_after)
+WHERE id = sqlc.arg(id);
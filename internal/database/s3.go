@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"path/filepath"
 	"time"
@@ -13,6 +14,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
+// StagingPrefix is where uploads are kept until they are promoted to their
+// scroll's key.
+const StagingPrefix = "staging/"
+
 type S3CFG struct {
 	BucketName string
 }
@@ -60,6 +65,75 @@ func (bucket *S3Bucket) StreamingUpload(reader io.Reader, key string) (*manager.
 	return output, err
 }
 
+// GetObject opens an object for reading. The caller must close it.
+func (bucket *S3Bucket) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := bucket.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket.cfg.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// GetObjectFrom opens an object for reading from the given byte offset on.
+// The caller must close it.
+func (bucket *S3Bucket) GetObjectFrom(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	output, err := bucket.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket.cfg.BucketName),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-", offset)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// ListObjects lists the objects under prefix whose keys sort after startAfter,
+// in key order.
+func (bucket *S3Bucket) ListObjects(ctx context.Context, prefix, startAfter string) ([]types.Object, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket.cfg.BucketName),
+		Prefix: aws.String(prefix),
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+	var objects []types.Object
+	p := s3.NewListObjectsV2Paginator(bucket.Client, input)
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Contents...)
+	}
+	return objects, nil
+}
+
+// CopyObject duplicates an object within the bucket without downloading it.
+func (bucket *S3Bucket) CopyObject(srcKey, dstKey string) error {
+	_, err := bucket.Client.CopyObject(context.Background(), &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket.cfg.BucketName),
+		CopySource: aws.String(bucket.cfg.BucketName + "/" + srcKey),
+		Key:        aws.String(dstKey),
+	})
+	return err
+}
+
+func (bucket *S3Bucket) DeleteObject(key string) error {
+	_, err := bucket.Client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket.cfg.BucketName),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// DeleteBatch deletes up to 1000 objects, returning the keys of those storage
+// refused to delete. If the request fails as a whole, none of the objects can
+// be taken as deleted and only the error is returned.
 func (bucket *S3Bucket) DeleteBatch(toDelete []types.ObjectIdentifier) ([]string, error) {
 	errKeys := make([]string, 0)
 	output, err := bucket.Client.DeleteObjects(context.Background(), &s3.DeleteObjectsInput{
@@ -70,7 +144,7 @@ func (bucket *S3Bucket) DeleteBatch(toDelete []types.ObjectIdentifier) ([]string
 		},
 	})
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	for _, error := range output.Errors {
 		errKeys = append(errKeys, *error.Key)
@@ -92,20 +166,22 @@ func (bucket *S3Bucket) NewAvilKeyIterator(ctx context.Context) *AvilKeyIterator
 	}
 }
 
-func (it *AvilKeyIterator) Next(ctx context.Context) (string, bool, error) {
+// Next returns the next object in the bucket, or false once there are no
+// more.
+func (it *AvilKeyIterator) Next(ctx context.Context) (types.Object, bool, error) {
 	if it.i < len(it.page) {
-		key := *it.page[it.i].Key
+		obj := it.page[it.i]
 		it.i++
-		return key, true, nil
+		return obj, true, nil
 	}
 
 	if !it.p.HasMorePages() {
-		return "", false, nil
+		return types.Object{}, false, nil
 	}
 
 	page, err := it.p.NextPage(ctx)
 	if err != nil {
-		return "", false, err
+		return types.Object{}, false, err
 	}
 
 	it.page = page.Contents
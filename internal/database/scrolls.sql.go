@@ -11,32 +11,80 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const deleteScroll = `-- name: DeleteScroll :exec
-DELETE FROM scroll WHERE id = $1
+const appendScroll = `-- name: AppendScroll :one
+UPDATE scroll
+SET appended_bytes = appended_bytes + $1
+WHERE id = $2 AND appendable AND NOT uploaded AND deleted_at IS NULL
+    AND appended_bytes + $1 <= $3
+RETURNING (appended_bytes - $1)::BIGINT AS start
 `
 
-func (q *Queries) DeleteScroll(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, deleteScroll, id)
+type AppendScrollParams struct {
+	Size    int64
+	ID      string
+	MaxSize int64
+}
+
+// Makes room for a chunk at the end of an appendable scroll that hasn't been
+// sealed, returning the offset the chunk starts at.
+func (q *Queries) AppendScroll(ctx context.Context, arg AppendScrollParams) (int64, error) {
+	row := q.db.QueryRow(ctx, appendScroll, arg.Size, arg.ID, arg.MaxSize)
+	var start int64
+	err := row.Scan(&start)
+	return start, err
+}
+
+const clearJarReadme = `-- name: ClearJarReadme :exec
+UPDATE scroll
+SET is_readme = FALSE
+WHERE jar_id = (SELECT jar_id FROM scroll WHERE scroll.id = $1) AND id <> $1 AND is_readme
+`
+
+// Unflags the README of the scroll's jar, unless it is the scroll itself.
+func (q *Queries) ClearJarReadme(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, clearJarReadme, id)
 	return err
 }
 
-const getExistingScrollIDs = `-- name: GetExistingScrollIDs :many
-SELECT id FROM scroll WHERE id = ANY($1::TEXT[])
+const countJarScrolls = `-- name: CountJarScrolls :one
+SELECT COUNT(*) FROM scroll WHERE jar_id = $1 AND uploaded = TRUE AND deleted_at IS NULL
+`
+
+func (q *Queries) CountJarScrolls(ctx context.Context, jarID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countJarScrolls, jarID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getExistingScrollKeys = `-- name: GetExistingScrollKeys :many
+SELECT (s.jar_id || '/' || s.id)::TEXT AS key, (s.appendable AND NOT s.uploaded)::BOOLEAN AS unsealed
+FROM scroll s
+JOIN unnest($1::TEXT[]) AS k(key)
+    ON s.id = split_part(k.key, '/', 2) AND s.jar_id = split_part(k.key, '/', 1)
 `
 
-func (q *Queries) GetExistingScrollIDs(ctx context.Context, dollar_1 []string) ([]string, error) {
-	rows, err := q.db.Query(ctx, getExistingScrollIDs, dollar_1)
+type GetExistingScrollKeysRow struct {
+	Key      string
+	Unsealed bool
+}
+
+// Keys are a jar ID and a scroll ID, the key a scroll's content is stored
+// under. Only keys of scrolls that are still in that jar are returned, along
+// with whether they are appendable scrolls whose chunks are still in use.
+func (q *Queries) GetExistingScrollKeys(ctx context.Context, dollar_1 []string) ([]GetExistingScrollKeysRow, error) {
+	rows, err := q.db.Query(ctx, getExistingScrollKeys, dollar_1)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetExistingScrollKeysRow
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var i GetExistingScrollKeysRow
+		if err := rows.Scan(&i.Key, &i.Unsealed); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -44,11 +92,41 @@ func (q *Queries) GetExistingScrollIDs(ctx context.Context, dollar_1 []string) (
 	return items, nil
 }
 
+const getJarReadme = `-- name: GetJarReadme :one
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
+FROM scroll s
+WHERE s.jar_id = $1 AND s.is_readme AND s.uploaded = TRUE AND s.deleted_at IS NULL
+`
+
+func (q *Queries) GetJarReadme(ctx context.Context, jarID string) (Scroll, error) {
+	row := q.db.QueryRow(ctx, getJarReadme, jarID)
+	var i Scroll
+	err := row.Scan(
+		&i.ID,
+		&i.JarID,
+		&i.Title,
+		&i.Format,
+		&i.Uploaded,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Position,
+		&i.Filename,
+		&i.FormatConfidence,
+		&i.IsReadme,
+		&i.Revision,
+		&i.Appendable,
+		&i.AppendedBytes,
+	)
+	return i, err
+}
+
 const getScroll = `-- name: GetScroll :one
-SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
 FROM scroll s
 JOIN scrolljar j ON j.id = s.jar_id
-WHERE s.id = $1 AND (j.expires_at IS NULL OR j.expires_at > now())
+WHERE s.id = $1 AND s.deleted_at IS NULL AND j.deleted_at IS NULL
+    AND (j.expires_at IS NULL OR j.expires_at > now())
 `
 
 func (q *Queries) GetScroll(ctx context.Context, id string) (Scroll, error) {
@@ -62,15 +140,25 @@ func (q *Queries) GetScroll(ctx context.Context, id string) (Scroll, error) {
 		&i.Uploaded,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Position,
+		&i.Filename,
+		&i.FormatConfidence,
+		&i.IsReadme,
+		&i.Revision,
+		&i.Appendable,
+		&i.AppendedBytes,
 	)
 	return i, err
 }
 
 const getScrollsByJar = `-- name: GetScrollsByJar :many
-SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
 FROM scroll s
 JOIN scrolljar j ON j.id = s.jar_id
-WHERE s.jar_id = $1 AND s.uploaded = TRUE AND (j.expires_at IS NULL OR j.expires_at > now())
+WHERE s.jar_id = $1 AND s.uploaded = TRUE AND s.deleted_at IS NULL AND j.deleted_at IS NULL
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+ORDER BY s.position, s.id
 `
 
 func (q *Queries) GetScrollsByJar(ctx context.Context, jarID string) ([]Scroll, error) {
@@ -90,6 +178,66 @@ func (q *Queries) GetScrollsByJar(ctx context.Context, jarID string) ([]Scroll,
 			&i.Uploaded,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Position,
+			&i.Filename,
+			&i.FormatConfidence,
+			&i.IsReadme,
+			&i.Revision,
+			&i.Appendable,
+			&i.AppendedBytes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrashedScrollsByUser = `-- name: GetTrashedScrollsByUser :many
+SELECT s.id, s.jar_id, s.title, s.format, s.uploaded, s.created_at, s.updated_at, s.deleted_at, s.position, s.filename, s.format_confidence, s.is_readme, s.revision, s.appendable, s.appended_bytes
+FROM scroll s
+JOIN scrolljar j ON j.id = s.jar_id
+WHERE j.user_id = $1 AND j.deleted_at IS NULL
+    AND s.deleted_at > $2
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+ORDER BY s.deleted_at DESC, s.id
+`
+
+type GetTrashedScrollsByUserParams struct {
+	UserID       pgtype.Int8
+	DeletedAfter pgtype.Timestamptz
+}
+
+// Scrolls of a trashed jar are listed through the jar instead.
+func (q *Queries) GetTrashedScrollsByUser(ctx context.Context, arg GetTrashedScrollsByUserParams) ([]Scroll, error) {
+	rows, err := q.db.Query(ctx, getTrashedScrollsByUser, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Scroll
+	for rows.Next() {
+		var i Scroll
+		if err := rows.Scan(
+			&i.ID,
+			&i.JarID,
+			&i.Title,
+			&i.Format,
+			&i.Uploaded,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Position,
+			&i.Filename,
+			&i.FormatConfidence,
+			&i.IsReadme,
+			&i.Revision,
+			&i.Appendable,
+			&i.AppendedBytes,
 		); err != nil {
 			return nil, err
 		}
@@ -102,24 +250,35 @@ func (q *Queries) GetScrollsByJar(ctx context.Context, jarID string) ([]Scroll,
 }
 
 const insertScroll = `-- name: InsertScroll :one
-INSERT INTO scroll (id, jar_id, title, format)
-VALUES ($1, $2, $3, $4)
-RETURNING id, jar_id, title, format, uploaded, created_at, updated_at
+INSERT INTO scroll (id, jar_id, title, format, format_confidence, filename, is_readme, appendable, appended_bytes, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, (SELECT COALESCE(MAX(position) + 1, 0) FROM scroll WHERE jar_id = $2))
+RETURNING id, jar_id, title, format, uploaded, created_at, updated_at, deleted_at, position, filename, format_confidence, is_readme, revision, appendable, appended_bytes
 `
 
 type InsertScrollParams struct {
-	ID     string
-	JarID  string
-	Title  pgtype.Text
-	Format pgtype.Text
+	ID               string
+	JarID            string
+	Title            pgtype.Text
+	Format           pgtype.Text
+	FormatConfidence pgtype.Float4
+	Filename         pgtype.Text
+	IsReadme         bool
+	Appendable       bool
+	AppendedBytes    int64
 }
 
+// New scrolls go after every other scroll in the jar.
 func (q *Queries) InsertScroll(ctx context.Context, arg InsertScrollParams) (Scroll, error) {
 	row := q.db.QueryRow(ctx, insertScroll,
 		arg.ID,
 		arg.JarID,
 		arg.Title,
 		arg.Format,
+		arg.FormatConfidence,
+		arg.Filename,
+		arg.IsReadme,
+		arg.Appendable,
+		arg.AppendedBytes,
 	)
 	var i Scroll
 	err := row.Scan(
@@ -130,13 +289,169 @@ func (q *Queries) InsertScroll(ctx context.Context, arg InsertScrollParams) (Scr
 		&i.Uploaded,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Position,
+		&i.Filename,
+		&i.FormatConfidence,
+		&i.IsReadme,
+		&i.Revision,
+		&i.Appendable,
+		&i.AppendedBytes,
 	)
 	return i, err
 }
 
+const lockAppendableScroll = `-- name: LockAppendableScroll :one
+SELECT appended_bytes, updated_at FROM scroll
+WHERE id = $1 AND appendable AND NOT uploaded AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type LockAppendableScrollRow struct {
+	AppendedBytes int64
+	UpdatedAt     pgtype.Timestamptz
+}
+
+// Holds off appends to a scroll that hasn't been sealed until the end of the
+// transaction.
+func (q *Queries) LockAppendableScroll(ctx context.Context, id string) (LockAppendableScrollRow, error) {
+	row := q.db.QueryRow(ctx, lockAppendableScroll, id)
+	var i LockAppendableScrollRow
+	err := row.Scan(&i.AppendedBytes, &i.UpdatedAt)
+	return i, err
+}
+
+const moveScroll = `-- name: MoveScroll :one
+UPDATE scroll
+SET jar_id = $1, is_readme = FALSE, position = (SELECT COALESCE(MAX(position) + 1, 0) FROM scroll WHERE jar_id = $1)
+WHERE id = $2 AND updated_at = $3 AND deleted_at IS NULL
+RETURNING updated_at
+`
+
+type MoveScrollParams struct {
+	JarID     string
+	ID        string
+	UpdatedAt pgtype.Timestamptz
+}
+
+// A moved scroll stops being a README, since its new jar may have one.
+func (q *Queries) MoveScroll(ctx context.Context, arg MoveScrollParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, moveScroll, arg.JarID, arg.ID, arg.UpdatedAt)
+	var updated_at pgtype.Timestamptz
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
+const purgeTrashedScrolls = `-- name: PurgeTrashedScrolls :execrows
+DELETE FROM scroll WHERE deleted_at <= $1
+`
+
+func (q *Queries) PurgeTrashedScrolls(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTrashedScrolls, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeUnuploadedScrolls = `-- name: PurgeUnuploadedScrolls :execrows
+DELETE FROM scroll
+WHERE NOT uploaded AND appended_bytes = 0 AND created_at <= $1
+`
+
+// Scrolls that were never uploaded are abandoned once their upload tokens
+// have expired. Appendable scrolls count while nothing was appended.
+func (q *Queries) PurgeUnuploadedScrolls(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeUnuploadedScrolls, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreJarScrolls = `-- name: RestoreJarScrolls :exec
+UPDATE scroll SET deleted_at = NULL
+WHERE jar_id = $1 AND deleted_at = $2
+`
+
+type RestoreJarScrollsParams struct {
+	JarID     string
+	DeletedAt pgtype.Timestamptz
+}
+
+// Brings back the scrolls that were trashed together with the jar.
+func (q *Queries) RestoreJarScrolls(ctx context.Context, arg RestoreJarScrollsParams) error {
+	_, err := q.db.Exec(ctx, restoreJarScrolls, arg.JarID, arg.DeletedAt)
+	return err
+}
+
+const restoreScroll = `-- name: RestoreScroll :execrows
+UPDATE scroll s SET deleted_at = NULL
+FROM scrolljar j
+WHERE s.id = $1 AND j.id = s.jar_id
+    AND j.user_id = $2 AND j.deleted_at IS NULL
+    AND s.deleted_at > $3
+    AND (j.expires_at IS NULL OR j.expires_at > now())
+`
+
+type RestoreScrollParams struct {
+	ID           string
+	UserID       pgtype.Int8
+	DeletedAfter pgtype.Timestamptz
+}
+
+func (q *Queries) RestoreScroll(ctx context.Context, arg RestoreScrollParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreScroll, arg.ID, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setScrollPositions = `-- name: SetScrollPositions :execrows
+UPDATE scroll s
+SET position = (o.position - 1)::INT
+FROM unnest($1::TEXT[]) WITH ORDINALITY AS o(id, position)
+WHERE s.id = o.id AND s.jar_id = $2 AND s.uploaded = TRUE AND s.deleted_at IS NULL
+`
+
+type SetScrollPositionsParams struct {
+	Ids   []string
+	JarID string
+}
+
+// Each scroll is placed at its index in the given list of IDs.
+func (q *Queries) SetScrollPositions(ctx context.Context, arg SetScrollPositionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setScrollPositions, arg.Ids, arg.JarID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setScrollFormatGuess = `-- name: SetScrollFormatGuess :one
+UPDATE scroll
+SET format = $1, format_confidence = $2
+WHERE id = $3
+RETURNING updated_at
+`
+
+type SetScrollFormatGuessParams struct {
+	Format           pgtype.Text
+	FormatConfidence pgtype.Float4
+	ID               string
+}
+
+func (q *Queries) SetScrollFormatGuess(ctx context.Context, arg SetScrollFormatGuessParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, setScrollFormatGuess, arg.Format, arg.FormatConfidence, arg.ID)
+	var updated_at pgtype.Timestamptz
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
 const setScrollUploaded = `-- name: SetScrollUploaded :one
 UPDATE scroll
-SET uploaded = TRUE
+SET uploaded = TRUE, revision = revision + 1
 WHERE id = $1 AND updated_at = $2
 RETURNING updated_at
 `
@@ -153,24 +468,52 @@ func (q *Queries) SetScrollUploaded(ctx context.Context, arg SetScrollUploadedPa
 	return updated_at, err
 }
 
+const setScrollsUploaded = `-- name: SetScrollsUploaded :exec
+UPDATE scroll SET uploaded = TRUE, revision = revision + 1 WHERE id = ANY($1::TEXT[])
+`
+
+func (q *Queries) SetScrollsUploaded(ctx context.Context, dollar_1 []string) error {
+	_, err := q.db.Exec(ctx, setScrollsUploaded, dollar_1)
+	return err
+}
+
+const trashScroll = `-- name: TrashScroll :execrows
+UPDATE scroll SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) TrashScroll(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, trashScroll, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateScroll = `-- name: UpdateScroll :one
 UPDATE scroll
-SET title = $1, format = $2
-WHERE id = $3 AND updated_at = $4
+SET title = $1, format = $2, format_confidence = $3, filename = $4, is_readme = $5
+WHERE id = $6 AND updated_at = $7
 RETURNING updated_at
 `
 
 type UpdateScrollParams struct {
-	Title     pgtype.Text
-	Format    pgtype.Text
-	ID        string
-	UpdatedAt pgtype.Timestamptz
+	Title            pgtype.Text
+	Format           pgtype.Text
+	FormatConfidence pgtype.Float4
+	Filename         pgtype.Text
+	IsReadme         bool
+	ID               string
+	UpdatedAt        pgtype.Timestamptz
 }
 
 func (q *Queries) UpdateScroll(ctx context.Context, arg UpdateScrollParams) (pgtype.Timestamptz, error) {
 	row := q.db.QueryRow(ctx, updateScroll,
 		arg.Title,
 		arg.Format,
+		arg.FormatConfidence,
+		arg.Filename,
+		arg.IsReadme,
 		arg.ID,
 		arg.UpdatedAt,
 	)
@@ -13,6 +13,8 @@ import (
 var (
 	ErrEditConflict  = errors.New("edit conflict")
 	ErrDuplicateUser = errors.New("duplicate email")
+	ErrDuplicateSlug = errors.New("duplicate slug")
+	ErrScrollOrder   = errors.New("scroll order does not match the jar")
 )
 
 type DBCFG struct {
@@ -22,6 +22,10 @@ const (
 	ScopeRefresh       = "refresh"
 )
 
+// DefaultTrashRetention is how long deleted jars and scrolls stay restorable
+// before the cleaner purges them.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
 // Store wraps Queries and provides a pool for transaction management.
 // High-level methods that compose multiple queries atomically live here.
 // Simple single-query operations are available via the embedded *Queries.
@@ -51,6 +55,20 @@ func (s *Store) GetJarsByUser(ctx context.Context, userID int64) ([]Scrolljar, e
 	return s.Queries.GetJarsByUser(ctx, pgtype.Int8{Int64: userID, Valid: true})
 }
 
+// SearchScrolls wraps the sqlc query to accept an optional user ID; a nil user
+// only sees public jars.
+func (s *Store) SearchScrolls(ctx context.Context, query string, userID *int64, limit, offset int32) ([]SearchScrollsRow, error) {
+	arg := SearchScrollsParams{
+		Query:     query,
+		RowLimit:  limit,
+		RowOffset: offset,
+	}
+	if userID != nil {
+		arg.UserID = pgtype.Int8{Int64: *userID, Valid: true}
+	}
+	return s.Queries.SearchScrolls(ctx, arg)
+}
+
 // InsertJar inserts a new jar, retrying on primary key collision.
 func (s *Store) InsertJar(ctx context.Context, arg InsertJarParams) (Scrolljar, error) {
 	return insertJarWithRetry(ctx, s.Queries, arg)
@@ -62,11 +80,22 @@ func (s *Store) InsertScroll(ctx context.Context, arg InsertScrollParams) (Scrol
 }
 
 // UpdateScroll maps pgx.ErrNoRows to ErrEditConflict for optimistic locking.
+// Flagging a scroll as its jar's README unflags the previous one.
 func (s *Store) UpdateScroll(ctx context.Context, arg UpdateScrollParams) (pgtype.Timestamptz, error) {
-	ts, err := s.Queries.UpdateScroll(ctx, arg)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return ts, ErrEditConflict
-	}
+	var ts pgtype.Timestamptz
+	err := s.withTx(ctx, func(q *Queries) error {
+		if arg.IsReadme {
+			if err := q.ClearJarReadme(ctx, arg.ID); err != nil {
+				return err
+			}
+		}
+		var err error
+		ts, err = q.UpdateScroll(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEditConflict
+		}
+		return err
+	})
 	return ts, err
 }
 
@@ -79,6 +108,180 @@ func (s *Store) SetScrollUploaded(ctx context.Context, arg SetScrollUploadedPara
 	return ts, err
 }
 
+// MoveScroll moves a scroll to another jar, whose key its content has been
+// copied to, and queues the object under the old key for deletion in the same
+// transaction. It maps pgx.ErrNoRows to ErrEditConflict for optimistic
+// locking.
+func (s *Store) MoveScroll(ctx context.Context, arg MoveScrollParams, keys InsertMovedScrollDeletionParams) (pgtype.Timestamptz, error) {
+	var ts pgtype.Timestamptz
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		ts, err = q.MoveScroll(ctx, arg)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEditConflict
+		}
+		if err != nil {
+			return err
+		}
+		return q.InsertMovedScrollDeletion(ctx, keys)
+	})
+	return ts, err
+}
+
+// InsertScrollCopy atomically creates a not yet uploaded copy of a scroll in
+// the given jar, along with its search index entry. The copy is marked
+// uploaded once its content has been copied in storage. Only uploaded scrolls
+// are copied, so an appendable scroll's content is all in its own object by
+// then, and the copy only needs to know how much was appended.
+func (s *Store) InsertScrollCopy(ctx context.Context, src Scroll, jarID string) (Scroll, error) {
+	var scroll Scroll
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		scroll, err = insertScrollWithRetry(ctx, q, InsertScrollParams{
+			JarID:            jarID,
+			Title:            src.Title,
+			Format:           src.Format,
+			FormatConfidence: src.FormatConfidence,
+			Filename:         src.Filename,
+			Appendable:       src.Appendable,
+			AppendedBytes:    src.AppendedBytes,
+		})
+		if err != nil {
+			return err
+		}
+		return q.CopyScrollContent(ctx, CopyScrollContentParams{
+			ToScrollID:   scroll.ID,
+			FromScrollID: src.ID,
+		})
+	})
+	return scroll, err
+}
+
+// CompleteScrollUpload atomically marks a scroll as uploaded and stores its
+// extracted text for full-text search. A guessed format, if given, is stored
+// along with it. promote is called last, while the scroll is locked, to put
+// the uploaded content in place; the scroll is only marked uploaded if it
+// succeeds.
+func (s *Store) CompleteScrollUpload(ctx context.Context, arg SetScrollUploadedParams, content string, guess *SetScrollFormatGuessParams, promote func() error) (pgtype.Timestamptz, error) {
+	var updatedAt pgtype.Timestamptz
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		updatedAt, err = q.SetScrollUploaded(ctx, arg)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrEditConflict
+			}
+			return err
+		}
+		if guess != nil {
+			updatedAt, err = q.SetScrollFormatGuess(ctx, *guess)
+			if err != nil {
+				return err
+			}
+		}
+		if err := q.UpsertScrollContent(ctx, UpsertScrollContentParams{
+			ScrollID: arg.ID,
+			Body:     content,
+		}); err != nil {
+			return err
+		}
+		return promote()
+	})
+	return updatedAt, err
+}
+
+// AppendScrollChunk makes room for a chunk at the end of an appendable scroll
+// and calls store with the offset it starts at. The room is only kept if store
+// succeeds, and appends to the same scroll wait for each other, so the chunks
+// stored for a scroll always cover its appended bytes without gaps.
+// Returns ErrEditConflict if the scroll is sealed or would grow past maxSize.
+func (s *Store) AppendScrollChunk(ctx context.Context, id string, size, maxSize int64, store func(start int64) error) (int64, error) {
+	var start int64
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		start, err = q.AppendScroll(ctx, AppendScrollParams{
+			Size:    size,
+			ID:      id,
+			MaxSize: maxSize,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrEditConflict
+			}
+			return err
+		}
+		return store(start)
+	})
+	return start, err
+}
+
+// SealScroll closes an appendable scroll to appends and marks it uploaded.
+// compose is called with the scroll's size while appends are held off, and
+// returns the text to index for search and a guessed format, if any. The
+// scroll's chunks are queued for deletion along with the seal.
+func (s *Store) SealScroll(ctx context.Context, id string, compose func(size int64) (string, *SetScrollFormatGuessParams, error)) (pgtype.Timestamptz, error) {
+	var updatedAt pgtype.Timestamptz
+	err := s.withTx(ctx, func(q *Queries) error {
+		locked, err := q.LockAppendableScroll(ctx, id)
+		if err != nil {
+			return err
+		}
+		content, guess, err := compose(locked.AppendedBytes)
+		if err != nil {
+			return err
+		}
+		updatedAt, err = q.SetScrollUploaded(ctx, SetScrollUploadedParams{
+			ID:        id,
+			UpdatedAt: locked.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if guess != nil {
+			updatedAt, err = q.SetScrollFormatGuess(ctx, *guess)
+			if err != nil {
+				return err
+			}
+		}
+		if err := q.InsertSegmentDeletion(ctx, id); err != nil {
+			return err
+		}
+		return q.UpsertScrollContent(ctx, UpsertScrollContentParams{
+			ScrollID: id,
+			Body:     content,
+		})
+	})
+	return updatedAt, err
+}
+
+// CompleteWebhookDelivery records a successful delivery, which ends its
+// webhook's run of failures.
+func (s *Store) CompleteWebhookDelivery(ctx context.Context, delivery ClaimWebhookDeliveriesRow, status int32) error {
+	return s.withTx(ctx, func(q *Queries) error {
+		if err := q.SetWebhookDelivered(ctx, SetWebhookDeliveredParams{
+			ResponseStatus: pgtype.Int4{Int32: status, Valid: true},
+			ID:             delivery.ID,
+		}); err != nil {
+			return err
+		}
+		return q.ResetWebhookFailures(ctx, delivery.WebhookID)
+	})
+}
+
+// FailWebhookDelivery records a failed delivery attempt, and disables its
+// webhook once disableAfter attempts in a row have failed.
+func (s *Store) FailWebhookDelivery(ctx context.Context, arg SetWebhookDeliveryFailedParams, webhookID int64, disableAfter int32) error {
+	return s.withTx(ctx, func(q *Queries) error {
+		if err := q.SetWebhookDeliveryFailed(ctx, arg); err != nil {
+			return err
+		}
+		return q.IncrementWebhookFailures(ctx, IncrementWebhookFailuresParams{
+			DisableAfter: disableAfter,
+			ID:           webhookID,
+		})
+	})
+}
+
 // InsertUser maps duplicate email errors.
 func (s *Store) InsertUser(ctx context.Context, arg InsertUserParams) (UserAccount, error) {
 	user, err := s.Queries.InsertUser(ctx, arg)
@@ -89,7 +292,8 @@ func (s *Store) InsertUser(ctx context.Context, arg InsertUserParams) (UserAccou
 	return user, err
 }
 
-// CreateJarWithScrolls atomically creates a jar and its initial scrolls.
+// CreateJarWithScrolls atomically creates a jar and its initial scrolls, which
+// are positioned in the jar in the order given.
 // Returns the jar, the scrolls, and the upload token for each scroll in order.
 func (s *Store) CreateJarWithScrolls(ctx context.Context, jarArg InsertJarParams, scrollArgs []InsertScrollParams) (Scrolljar, []Scroll, error) {
 	var jar Scrolljar
@@ -114,13 +318,56 @@ func (s *Store) CreateJarWithScrolls(ctx context.Context, jarArg InsertJarParams
 	return jar, scrolls, err
 }
 
-// CreateUserWithActivationToken atomically inserts a user and an activation token.
-// Returns the user and the plain-text token.
-func (s *Store) CreateUserWithActivationToken(ctx context.Context, arg InsertUserParams) (UserAccount, string, error) {
-	var user UserAccount
-	tokenText, tokenHash := newToken()
-	expiry := time.Now().Add(5 * time.Minute)
+// ForkJar atomically creates a public copy of a jar owned by the user, with a
+// not yet uploaded copy of each of the given scrolls and their search index
+// entries. The scrolls are marked uploaded with SetScrollsUploaded once their
+// content has been copied in storage.
+func (s *Store) ForkJar(ctx context.Context, src Scrolljar, userID int64, srcScrolls []Scroll) (Scrolljar, []Scroll, error) {
+	var jar Scrolljar
+	var scrolls []Scroll
 
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		jar, err = insertJarWithRetry(ctx, q, InsertJarParams{
+			UserID:     pgtype.Int8{Int64: userID, Valid: true},
+			Name:       src.Name,
+			Tags:       src.Tags,
+			ForkedFrom: pgtype.Text{String: src.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		for _, srcScroll := range srcScrolls {
+			scroll, err := insertScrollWithRetry(ctx, q, InsertScrollParams{
+				JarID:            jar.ID,
+				Title:            srcScroll.Title,
+				Format:           srcScroll.Format,
+				FormatConfidence: srcScroll.FormatConfidence,
+				Filename:         srcScroll.Filename,
+				IsReadme:         srcScroll.IsReadme,
+				Appendable:       srcScroll.Appendable,
+				AppendedBytes:    srcScroll.AppendedBytes,
+			})
+			if err != nil {
+				return err
+			}
+			if err := q.CopyScrollContent(ctx, CopyScrollContentParams{
+				ToScrollID:   scroll.ID,
+				FromScrollID: srcScroll.ID,
+			}); err != nil {
+				return err
+			}
+			scrolls = append(scrolls, scroll)
+		}
+		return nil
+	})
+	return jar, scrolls, err
+}
+
+// CreateUserWithJob atomically inserts a user and a job made for them, so
+// the job runs exactly when the user exists.
+func (s *Store) CreateUserWithJob(ctx context.Context, arg InsertUserParams, job func(user UserAccount) (InsertJobParams, error)) (UserAccount, error) {
+	var user UserAccount
 	err := s.withTx(ctx, func(q *Queries) error {
 		var err error
 		user, err = q.InsertUser(ctx, arg)
@@ -131,14 +378,34 @@ func (s *Store) CreateUserWithActivationToken(ctx context.Context, arg InsertUse
 			}
 			return err
 		}
-		return q.UpsertToken(ctx, UpsertTokenParams{
-			TokenHash: tokenHash[:],
-			UserID:    user.ID,
-			ExpiresAt: pgtype.Timestamptz{Time: expiry, Valid: true},
-			Scope:     ScopeActivation,
-		})
+		jobArg, err := job(user)
+		if err != nil {
+			return err
+		}
+		_, err = q.InsertJob(ctx, jobArg)
+		return err
+	})
+	return user, err
+}
+
+// UpsertJarTransferWithJob atomically offers a jar to another user and queues
+// a job made for the offer, so the job runs exactly when the offer is made.
+func (s *Store) UpsertJarTransferWithJob(ctx context.Context, arg UpsertJarTransferParams, job func(transfer JarTransfer) (InsertJobParams, error)) (JarTransfer, error) {
+	var transfer JarTransfer
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		transfer, err = q.UpsertJarTransfer(ctx, arg)
+		if err != nil {
+			return err
+		}
+		jobArg, err := job(transfer)
+		if err != nil {
+			return err
+		}
+		_, err = q.InsertJob(ctx, jobArg)
+		return err
 	})
-	return user, tokenText, err
+	return transfer, err
 }
 
 // ActivateUser atomically validates an activation token and marks the user as active.
@@ -227,6 +494,243 @@ func (s *Store) CreateActivationToken(ctx context.Context, userID int64) (string
 	return tokenText, expiry, err
 }
 
+// CreateShareLink inserts a share link for a jar.
+// Returns the link and its plain-text token; only the hash is stored.
+func (s *Store) CreateShareLink(ctx context.Context, jarID string, expiry time.Time, maxUses *int32) (JarShareLink, string, error) {
+	tokenText, tokenHash := newToken()
+	arg := InsertShareLinkParams{
+		JarID:     jarID,
+		TokenHash: tokenHash[:],
+		ExpiresAt: pgtype.Timestamptz{Time: expiry, Valid: true},
+	}
+	if maxUses != nil {
+		arg.MaxUses = pgtype.Int4{Int32: *maxUses, Valid: true}
+	}
+	link, err := s.Queries.InsertShareLink(ctx, arg)
+	return link, tokenText, err
+}
+
+// ClaimJarUnlockAttempt atomically counts a password attempt against a jar
+// before the password is checked, so parallel guesses each use one up, and
+// locks the jar for the duration lockout returns for the new count. It returns
+// false if the jar is already locked, and otherwise the time this attempt
+// locked the jar until, which is zero if it didn't.
+func (s *Store) ClaimJarUnlockAttempt(ctx context.Context, jarID string, lockout func(attempts int32) time.Duration) (bool, time.Time, error) {
+	var lockedUntil time.Time
+	allowed := true
+	err := s.withTx(ctx, func(q *Queries) error {
+		attempts, err := q.CountJarUnlockAttempt(ctx, jarID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			allowed = false
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		d := lockout(attempts)
+		if d <= 0 {
+			return nil
+		}
+		lockedUntil = time.Now().Add(d)
+		return q.SetJarLockedUntil(ctx, SetJarLockedUntilParams{
+			JarID:       jarID,
+			LockedUntil: pgtype.Timestamptz{Time: lockedUntil, Valid: true},
+		})
+	})
+	return allowed, lockedUntil, err
+}
+
+// GetUserTrash returns the user's jars and scrolls trashed after deletedAfter,
+// most recently deleted first.
+func (s *Store) GetUserTrash(ctx context.Context, userID int64, deletedAfter time.Time) ([]Scrolljar, []Scroll, error) {
+	user := pgtype.Int8{Int64: userID, Valid: true}
+	after := pgtype.Timestamptz{Time: deletedAfter, Valid: true}
+	jars, err := s.Queries.GetTrashedJarsByUser(ctx, GetTrashedJarsByUserParams{
+		UserID:       user,
+		DeletedAfter: after,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	scrolls, err := s.Queries.GetTrashedScrollsByUser(ctx, GetTrashedScrollsByUserParams{
+		UserID:       user,
+		DeletedAfter: after,
+	})
+	return jars, scrolls, err
+}
+
+// RestoreJar atomically takes a jar the user owns out of the trash, along with
+// the scrolls that were trashed with it. Jars trashed before deletedAfter
+// can't be restored and are reported as pgx.ErrNoRows.
+func (s *Store) RestoreJar(ctx context.Context, id string, userID int64, deletedAfter time.Time) (Scrolljar, error) {
+	var jar Scrolljar
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		jar, err = q.GetTrashedJarForUpdate(ctx, GetTrashedJarForUpdateParams{
+			ID:           id,
+			UserID:       pgtype.Int8{Int64: userID, Valid: true},
+			DeletedAfter: pgtype.Timestamptz{Time: deletedAfter, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.RestoreJarScrolls(ctx, RestoreJarScrollsParams{
+			JarID:     jar.ID,
+			DeletedAt: jar.DeletedAt,
+		}); err != nil {
+			return err
+		}
+		jar.DeletedAt = pgtype.Timestamptz{}
+		return q.RestoreJar(ctx, jar.ID)
+	})
+	return jar, err
+}
+
+// RestoreScroll takes a scroll out of the trash if the user owns its live jar.
+// Returns pgx.ErrNoRows if there is no such scroll within the window.
+func (s *Store) RestoreScroll(ctx context.Context, id string, userID int64, deletedAfter time.Time) error {
+	n, err := s.Queries.RestoreScroll(ctx, RestoreScrollParams{
+		ID:           id,
+		UserID:       pgtype.Int8{Int64: userID, Valid: true},
+		DeletedAfter: pgtype.Timestamptz{Time: deletedAfter, Valid: true},
+	})
+	if err == nil && n == 0 {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// PurgeTrash permanently deletes the scrolls and jars trashed at or before
+// deletedBefore. Returns the number of scrolls and jars removed; scrolls that
+// go with a purged jar aren't counted.
+func (s *Store) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	before := pgtype.Timestamptz{Time: deletedBefore, Valid: true}
+	scrolls, err := s.Queries.PurgeTrashedScrolls(ctx, before)
+	if err != nil {
+		return 0, 0, err
+	}
+	jars, err := s.Queries.PurgeTrashedJars(ctx, before)
+	return scrolls, jars, err
+}
+
+// SetJarSlug atomically gives a jar a new slug. Every slug a jar has or had is
+// kept in jar_slug_redirect, whose primary key lets only one jar ever claim a
+// slug, so the jar's previous slug keeps resolving to it and a slug it used
+// before can be reclaimed. Returns ErrDuplicateSlug if another jar has or had
+// the slug.
+func (s *Store) SetJarSlug(ctx context.Context, jarID, slug string) (Scrolljar, error) {
+	var jar Scrolljar
+	err := s.withTx(ctx, func(q *Queries) error {
+		if err := q.DeleteSlugRedirect(ctx, DeleteSlugRedirectParams{
+			Slug:  slug,
+			JarID: jarID,
+		}); err != nil {
+			return err
+		}
+		err := q.InsertSlugRedirect(ctx, InsertSlugRedirectParams{
+			Slug:  slug,
+			JarID: jarID,
+		})
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "jar_slug_redirect_pkey" {
+			return ErrDuplicateSlug
+		}
+		if err != nil {
+			return err
+		}
+		jar, err = q.SetJarSlug(ctx, SetJarSlugParams{
+			ID:   jarID,
+			Slug: pgtype.Text{String: slug, Valid: true},
+		})
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "scrolljar_slug_key" {
+			return ErrDuplicateSlug
+		}
+		return err
+	})
+	return jar, err
+}
+
+// ReorderJarScrolls atomically sets the order of a jar's scrolls to that of
+// the given IDs, which must list every uploaded scroll in the jar exactly once.
+// The jar's updated_at is used for optimistic locking, so two clients
+// reordering at once can't interleave. Returns the jar's new updated_at,
+// ErrEditConflict if the jar has changed, or ErrScrollOrder if the IDs don't
+// match the jar's scrolls.
+func (s *Store) ReorderJarScrolls(ctx context.Context, jarID string, ids []string, updatedAt time.Time) (pgtype.Timestamptz, error) {
+	var ts pgtype.Timestamptz
+	err := s.withTx(ctx, func(q *Queries) error {
+		var err error
+		ts, err = q.TouchJar(ctx, TouchJarParams{
+			ID:        jarID,
+			UpdatedAt: pgtype.Timestamptz{Time: updatedAt, Valid: true},
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrEditConflict
+			}
+			return err
+		}
+		count, err := q.CountJarScrolls(ctx, jarID)
+		if err != nil {
+			return err
+		}
+		n, err := q.SetScrollPositions(ctx, SetScrollPositionsParams{
+			Ids:   ids,
+			JarID: jarID,
+		})
+		if err != nil {
+			return err
+		}
+		// A duplicate or foreign ID updates fewer rows than were given.
+		if n != int64(len(ids)) || n != count {
+			return ErrScrollOrder
+		}
+		return nil
+	})
+	return ts, err
+}
+
+// AcceptJarTransfer atomically hands a jar to the user a pending transfer was
+// offered to. The new owner's membership, if any, is dropped as ownership
+// supersedes it. Returns pgx.ErrNoRows if there is no such transfer or the jar
+// has since changed hands or been deleted.
+func (s *Store) AcceptJarTransfer(ctx context.Context, id int64, userID int64) (string, error) {
+	var jarID string
+	err := s.withTx(ctx, func(q *Queries) error {
+		transfer, err := q.GetJarTransferForUpdate(ctx, GetJarTransferForUpdateParams{
+			ID:       id,
+			ToUserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		n, err := q.SetJarOwner(ctx, SetJarOwnerParams{
+			ToUserID:   pgtype.Int8{Int64: transfer.ToUserID, Valid: true},
+			ID:         transfer.JarID,
+			FromUserID: pgtype.Int8{Int64: transfer.FromUserID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return pgx.ErrNoRows
+		}
+		if _, err := q.DeleteJarMember(ctx, DeleteJarMemberParams{
+			JarID:  transfer.JarID,
+			UserID: transfer.ToUserID,
+		}); err != nil {
+			return err
+		}
+		jarID = transfer.JarID
+		_, err = q.DeleteJarTransfer(ctx, DeleteJarTransferParams{
+			ID:     transfer.ID,
+			UserID: userID,
+		})
+		return err
+	})
+	return jarID, err
+}
+
 // newToken generates a random token text and its SHA-256 hash.
 func newToken() (string, [32]byte) {
 	text := rand.Text()
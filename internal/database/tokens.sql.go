@@ -11,13 +11,16 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const deleteExpiredTokens = `-- name: DeleteExpiredTokens :exec
+const deleteExpiredTokens = `-- name: DeleteExpiredTokens :execrows
 DELETE FROM token WHERE expires_at <= now()
 `
 
-func (q *Queries) DeleteExpiredTokens(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteExpiredTokens)
-	return err
+func (q *Queries) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteTokenByHash = `-- name: DeleteTokenByHash :exec
@@ -11,6 +11,41 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getActivatedUsersByUsername = `-- name: GetActivatedUsersByUsername :many
+SELECT id, username, email, password_hash, activated, created_at, updated_at
+FROM user_account
+WHERE username = $1 AND activated = TRUE
+LIMIT 2
+`
+
+func (q *Queries) GetActivatedUsersByUsername(ctx context.Context, username string) ([]UserAccount, error) {
+	rows, err := q.db.Query(ctx, getActivatedUsersByUsername, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserAccount
+	for rows.Next() {
+		var i UserAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.PasswordHash,
+			&i.Activated,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT id, username, email, password_hash, activated, created_at, updated_at
 FROM user_account
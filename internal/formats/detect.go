@@ -0,0 +1,280 @@
+package formats
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// sampleSize is how much of the content the keyword heuristics look at.
+const sampleSize = 16 * 1024
+
+// minConfidence is the lowest confidence a guess is returned with.
+const minConfidence = 0.3
+
+var (
+	// vim: set ft=python: / vim: filetype=go / vi: ft=sh
+	vimModeline = regexp.MustCompile(`(?:vim?|ex):.*\b(?:ft|filetype|syntax)=([A-Za-z0-9_+#-]+)`)
+	// -*- mode: python -*- / -*- python -*-
+	emacsModeline = regexp.MustCompile(`-\*-\s*(?:.*\bmode:\s*)?([A-Za-z0-9_+#-]+)\s*(?:;.*)?-\*-`)
+	versionSuffix = regexp.MustCompile(`[0-9.]+$`)
+)
+
+// interpreters maps shebang interpreters to formats where the interpreter
+// isn't already a name or alias.
+var interpreters = map[string]string{
+	"ash":    "bash",
+	"dash":   "bash",
+	"ksh":    "bash",
+	"python": "python",
+	"pypy":   "python",
+	"deno":   "typescript",
+	"bun":    "javascript",
+	"php":    "php",
+}
+
+type heuristic struct {
+	pattern *regexp.Regexp
+	weight  int
+}
+
+// heuristics are per-format patterns that are telling of the format. A line
+// can count towards several formats; the best scoring format wins.
+var heuristics = map[string][]heuristic{
+	"go": {
+		{regexp.MustCompile(`(?m)^package [a-z_][a-z0-9_]*\s*$`), 5},
+		{regexp.MustCompile(`(?m)^func (\([^)]*\) )?[A-Za-z_]\w*\(`), 4},
+		{regexp.MustCompile(`:= `), 1},
+		{regexp.MustCompile(`(?m)^import \($`), 3},
+		{regexp.MustCompile(`\bif err != nil\b`), 4},
+	},
+	"python": {
+		{regexp.MustCompile(`(?m)^\s*def [A-Za-z_]\w*\(.*\):\s*$`), 4},
+		{regexp.MustCompile(`(?m)^\s*(from [\w.]+ )?import [\w.]+(, [\w.]+)*( as \w+)?\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s*class \w+(\(.*\))?:\s*$`), 4},
+		{regexp.MustCompile(`if __name__ == ['"]__main__['"]:`), 5},
+		{regexp.MustCompile(`(?m)^\s*(elif|except|finally)\b.*:\s*$`), 3},
+		{regexp.MustCompile(`\bself\.`), 1},
+	},
+	"javascript": {
+		{regexp.MustCompile(`(?m)^\s*(const|let|var) \w+ = `), 2},
+		{regexp.MustCompile(`\bfunction\s*\w*\s*\(`), 2},
+		{regexp.MustCompile(`=> \{`), 2},
+		{regexp.MustCompile(`\brequire\(['"]`), 3},
+		{regexp.MustCompile(`\bconsole\.log\(`), 3},
+		{regexp.MustCompile(`\bmodule\.exports\b`), 4},
+	},
+	"typescript": {
+		{regexp.MustCompile(`(?m)^\s*(export )?(interface|type) \w+(<.*>)? (=|\{)`), 5},
+		{regexp.MustCompile(`\w\??: (string|number|boolean|any|unknown|void)\b`), 3},
+		{regexp.MustCompile(`(?m)^import .* from ['"]`), 1},
+	},
+	"rust": {
+		{regexp.MustCompile(`(?m)^\s*(pub )?fn \w+(<.*>)?\(`), 4},
+		{regexp.MustCompile(`\blet mut \w+`), 4},
+		{regexp.MustCompile(`(?m)^\s*use \w+(::\w+)+`), 3},
+		{regexp.MustCompile(`\bimpl(<.*>)? \w+`), 3},
+		{regexp.MustCompile(`\w+!\(`), 1},
+	},
+	"c": {
+		{regexp.MustCompile(`(?m)^#include <\w+\.h>`), 4},
+		{regexp.MustCompile(`\bint main\s*\(`), 3},
+		{regexp.MustCompile(`\bprintf\(`), 2},
+		{regexp.MustCompile(`\bmalloc\(`), 2},
+	},
+	"cpp": {
+		{regexp.MustCompile(`(?m)^#include <[a-z_]+>`), 4},
+		{regexp.MustCompile(`\bstd::`), 4},
+		{regexp.MustCompile(`(?m)^\s*(template\s*<|namespace \w+)`), 4},
+		{regexp.MustCompile(`\bcout\s*<<`), 3},
+	},
+	"java": {
+		{regexp.MustCompile(`(?m)^\s*public (final )?(class|interface|enum) \w+`), 4},
+		{regexp.MustCompile(`public static void main\(String`), 5},
+		{regexp.MustCompile(`(?m)^import java\.`), 5},
+		{regexp.MustCompile(`System\.out\.print`), 4},
+	},
+	"csharp": {
+		{regexp.MustCompile(`(?m)^using System(\.\w+)*;`), 5},
+		{regexp.MustCompile(`(?m)^\s*namespace [\w.]+\s*(\{|;)?\s*$`), 2},
+		{regexp.MustCompile(`Console\.Write(Line)?\(`), 4},
+	},
+	"ruby": {
+		{regexp.MustCompile(`(?m)^\s*def \w+[?!]?(\(.*\))?\s*$`), 3},
+		{regexp.MustCompile(`(?m)^\s*end\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s*require ['"]`), 3},
+		{regexp.MustCompile(`\bputs\b`), 2},
+		{regexp.MustCompile(`\.each do \|`), 4},
+	},
+	"php": {
+		{regexp.MustCompile(`<\?php`), 10},
+		{regexp.MustCompile(`\$\w+->`), 2},
+	},
+	"bash": {
+		{regexp.MustCompile(`(?m)^\s*(if|while) \[\[? .* \]\]?; (then|do)`), 4},
+		{regexp.MustCompile(`(?m)^\s*(fi|done|esac)\s*$`), 3},
+		{regexp.MustCompile(`(?m)^\s*(export )?[A-Z_][A-Z0-9_]*=`), 1},
+		{regexp.MustCompile(`\$\{?\w+\}?`), 1},
+		{regexp.MustCompile(`(?m)^\s*(sudo |apt(-get)? |echo |cd |mkdir |rm -)`), 2},
+	},
+	"sql": {
+		{regexp.MustCompile(`(?i)\bselect\b[\s\S]+?\bfrom\b`), 3},
+		{regexp.MustCompile(`(?i)\b(insert into|update \w+ set|delete from)\b`), 4},
+		{regexp.MustCompile(`(?i)\bcreate (table|index|view|function)\b`), 5},
+		{regexp.MustCompile(`(?i)\b(inner|left|right) join\b`), 3},
+	},
+	"html": {
+		{regexp.MustCompile(`(?i)<!doctype html>`), 10},
+		{regexp.MustCompile(`(?i)</?(html|head|body|div|span|script|p|a)\b[^>]*>`), 2},
+	},
+	"xml": {
+		{regexp.MustCompile(`^\s*<\?xml `), 10},
+		{regexp.MustCompile(`</[\w:]+>`), 1},
+	},
+	"css": {
+		{regexp.MustCompile(`(?m)^\s*[.#]?[\w-]+(\s*[,>+~]?\s*[.#:]?[\w-]+)*\s*\{\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s*[a-z-]+: [^;]+;\s*$`), 2},
+		{regexp.MustCompile(`@media\b`), 4},
+	},
+	"markdown": {
+		{regexp.MustCompile(`(?m)^#{1,6} \S`), 3},
+		{regexp.MustCompile("(?m)^```"), 3},
+		{regexp.MustCompile(`\[[^\]]+\]\([^)]+\)`), 3},
+		{regexp.MustCompile(`(?m)^\s*[-*] \S`), 1},
+	},
+	"yaml": {
+		{regexp.MustCompile(`(?m)^---\s*$`), 2},
+		{regexp.MustCompile(`(?m)^[\w-]+:(\s+\S.*)?$`), 2},
+		{regexp.MustCompile(`(?m)^\s+- [\w-]+:`), 2},
+	},
+	"diff": {
+		{regexp.MustCompile(`(?m)^(---|\+\+\+) \S`), 4},
+		{regexp.MustCompile(`(?m)^@@ -\d+(,\d+)? \+\d+(,\d+)? @@`), 8},
+		{regexp.MustCompile(`(?m)^diff --git `), 8},
+	},
+	"docker": {
+		{regexp.MustCompile(`(?m)^FROM \S+`), 5},
+		{regexp.MustCompile(`(?m)^(RUN|COPY|ADD|WORKDIR|ENTRYPOINT|CMD|EXPOSE) `), 3},
+	},
+	"ini": {
+		{regexp.MustCompile(`(?m)^\[[\w .-]+\]\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\w+\s*=\s*.*$`), 1},
+	},
+	"toml": {
+		{regexp.MustCompile(`(?m)^\[\[?[\w.-]+\]\]?\s*$`), 2},
+		{regexp.MustCompile(`(?m)^[\w-]+ = (".*"|\d+|true|false|\[)`), 2},
+	},
+}
+
+// Detect guesses the format of a scroll from its filename and a sample of its
+// content, returning the format's name and a confidence between 0 and 1. The
+// name is empty if no format could be guessed with any confidence.
+//
+// Explicit signals are tried first: a known filename or extension, then a
+// shebang, then an editor modeline. Failing those, the content is scored
+// against per-format keyword heuristics.
+func Detect(filename, content string) (string, float32) {
+	if f, ok := FromFilename(filename); ok {
+		return f.Name, 0.95
+	}
+	if name, ok := fromShebang(content); ok {
+		return name, 0.95
+	}
+	if name, ok := fromModeline(content); ok {
+		return name, 0.9
+	}
+
+	sample := content[:min(len(content), sampleSize)]
+	trimmed := strings.TrimSpace(sample)
+	if trimmed == "" {
+		return "", 0
+	}
+	if (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)) {
+		return "json", 0.9
+	}
+	return fromHeuristics(sample)
+}
+
+func fromShebang(content string) (string, bool) {
+	if !strings.HasPrefix(content, "#!") {
+		return "", false
+	}
+	line, _, _ := strings.Cut(content[2:], "\n")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", false
+	}
+	interp := path.Base(fields[0])
+	// #!/usr/bin/env [-S] python3
+	if interp == "env" {
+		fields = fields[1:]
+		for len(fields) > 0 && strings.HasPrefix(fields[0], "-") {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return "", false
+		}
+		interp = path.Base(fields[0])
+	}
+	if f, ok := Lookup(interp); ok {
+		return f.Name, true
+	}
+	interp = versionSuffix.ReplaceAllString(interp, "")
+	if f, ok := Lookup(interp); ok {
+		return f.Name, true
+	}
+	name, ok := interpreters[interp]
+	return name, ok
+}
+
+// fromModeline looks for a vim or emacs modeline in the first and last few
+// lines, which is where editors look for them.
+func fromModeline(content string) (string, bool) {
+	lines := strings.SplitN(content, "\n", 6)
+	head := strings.Join(lines[:min(len(lines), 5)], "\n")
+	tail := content[max(0, len(content)-1024):]
+	for _, text := range []string{head, tail} {
+		for _, rx := range []*regexp.Regexp{vimModeline, emacsModeline} {
+			if m := rx.FindStringSubmatch(text); m != nil {
+				if f, ok := Lookup(m[1]); ok {
+					return f.Name, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// fromHeuristics scores the sample against every format's heuristics. The
+// confidence is the winner's share of the total score, scaled down when the
+// total is low since a handful of matches says little.
+func fromHeuristics(sample string) (string, float32) {
+	var best string
+	var bestScore, total int
+	for name, hs := range heuristics {
+		score := 0
+		for _, h := range hs {
+			// Count each pattern a few times at most, so one common token can't
+			// drown out everything else.
+			score += h.weight * min(len(h.pattern.FindAllStringIndex(sample, 5)), 5)
+		}
+		total += score
+		if score > bestScore || (score == bestScore && score > 0 && name < best) {
+			best, bestScore = name, score
+		}
+	}
+	if bestScore == 0 {
+		return "", 0
+	}
+	confidence := float32(bestScore) / float32(total)
+	if bestScore < 10 {
+		confidence *= float32(bestScore) / 10
+	}
+	// Keyword matches are never as sure as an explicit signal.
+	confidence = min(confidence, 0.85)
+	if confidence < minConfidence {
+		return "", 0
+	}
+	return best, confidence
+}
@@ -0,0 +1,47 @@
+package formats
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     string
+	}{
+		{"extension", "main.go", "", "go"},
+		{"extension over content", "notes.md", "package main\n", "markdown"},
+		{"file name", "Dockerfile", "", "docker"},
+		{"shebang", "", "#!/bin/bash\necho hi\n", "bash"},
+		{"env shebang", "", "#!/usr/bin/env python3\nprint('hi')\n", "python"},
+		{"env -S shebang", "", "#!/usr/bin/env -S node --no-warnings\n", "javascript"},
+		{"versioned interpreter", "", "#!/usr/bin/python3.12\n", "python"},
+		{"interpreter without a format", "", "#!/bin/dash\n", "bash"},
+		{"unknown interpreter", "", "#!/usr/bin/frobnicate\n", ""},
+		{"vim modeline", "", "# vim: set ft=python:\nx = 1\n", "python"},
+		{"vim modeline at the end", "", "x = 1\n\n\n\n\n\n\n// vim: filetype=go\n", "go"},
+		{"emacs modeline", "", "// -*- mode: go -*-\n", "go"},
+		{"json", "", `{"a": [1, 2]}`, "json"},
+		{"invalid json", "", `{"a": `, ""},
+		{"go", "", "package main\n\nimport (\n\t\"fmt\"\n)\n\nfunc main() {\n\tif err != nil {\n\t}\n}\n", "go"},
+		{"python", "", "import os\n\ndef main():\n    pass\n\nif __name__ == '__main__':\n    main()\n", "python"},
+		{"php", "", "<?php\necho 'hi';\n", "php"},
+		{"empty", "", "", ""},
+		{"blank", "", " \n\t\n", ""},
+		{"prose", "", "just a few words of plain text", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, confidence := Detect(tt.filename, tt.content)
+			if got != tt.want {
+				t.Errorf("Detect(%q, %q) = %q, want %q", tt.filename, tt.content, got, tt.want)
+			}
+			if got == "" && confidence != 0 {
+				t.Errorf("no format but confidence %v", confidence)
+			}
+			if got != "" && (confidence < minConfidence || confidence > 1) {
+				t.Errorf("confidence %v out of range", confidence)
+			}
+		})
+	}
+}
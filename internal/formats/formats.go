@@ -0,0 +1,103 @@
+// Package formats is the registry of scroll formats the server knows about.
+// Every format has a canonical name, which is what gets stored on a scroll,
+// along with the aliases, file extensions and MIME type it is recognized by.
+package formats
+
+import (
+	"path"
+	"strings"
+)
+
+type Format struct {
+	Name       string
+	Aliases    []string
+	Extensions []string
+	MIMEType   string
+}
+
+// Text is the format of scrolls that are plain text.
+const Text = "text"
+
+// Markdown is the format of scrolls that are rendered rather than highlighted.
+const Markdown = "markdown"
+
+var registry = []Format{
+	{Text, []string{"plain", "plaintext", "txt"}, []string{".txt", ".text"}, "text/plain"},
+	{"bash", []string{"sh", "shell", "zsh"}, []string{".sh", ".bash", ".zsh"}, "application/x-sh"},
+	{"c", []string{"h"}, []string{".c", ".h"}, "text/x-csrc"},
+	{"cpp", []string{"c++", "cxx", "hpp"}, []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx"}, "text/x-c++src"},
+	{"csharp", []string{"c#", "cs"}, []string{".cs"}, "text/x-csharp"},
+	{"css", nil, []string{".css"}, "text/css"},
+	{"diff", []string{"patch", "udiff"}, []string{".diff", ".patch"}, "text/x-diff"},
+	{"docker", []string{"dockerfile"}, nil, "text/x-dockerfile"},
+	{"go", []string{"golang"}, []string{".go"}, "text/x-go"},
+	{"html", []string{"htm", "xhtml"}, []string{".html", ".htm", ".xhtml"}, "text/html"},
+	{"ini", []string{"cfg", "dosini"}, []string{".ini", ".cfg"}, "text/x-ini"},
+	{"java", nil, []string{".java"}, "text/x-java"},
+	{"javascript", []string{"js", "node", "nodejs"}, []string{".js", ".mjs", ".cjs"}, "text/javascript"},
+	{"json", nil, []string{".json"}, "application/json"},
+	{"kotlin", []string{"kt"}, []string{".kt", ".kts"}, "text/x-kotlin"},
+	{"lua", nil, []string{".lua"}, "text/x-lua"},
+	{"makefile", []string{"make", "mf"}, []string{".mk", ".mak"}, "text/x-makefile"},
+	{Markdown, []string{"md", "mkd"}, []string{".md", ".markdown", ".mkd"}, "text/markdown"},
+	{"perl", []string{"pl"}, []string{".pl", ".pm"}, "text/x-perl"},
+	{"php", nil, []string{".php"}, "application/x-php"},
+	{"python", []string{"py", "python3", "py3"}, []string{".py", ".pyw"}, "text/x-python"},
+	{"ruby", []string{"rb"}, []string{".rb"}, "text/x-ruby"},
+	{"rust", []string{"rs"}, []string{".rs"}, "text/x-rust"},
+	{"scala", nil, []string{".scala"}, "text/x-scala"},
+	{"sql", []string{"postgresql", "postgres", "mysql"}, []string{".sql"}, "application/sql"},
+	{"swift", nil, []string{".swift"}, "text/x-swift"},
+	{"toml", nil, []string{".toml"}, "application/toml"},
+	{"typescript", []string{"ts"}, []string{".ts", ".mts", ".cts"}, "application/typescript"},
+	{"xml", nil, []string{".xml", ".xsd", ".xsl", ".svg"}, "application/xml"},
+	{"yaml", []string{"yml"}, []string{".yaml", ".yml"}, "application/yaml"},
+}
+
+// filenames maps well-known extensionless file names to their format.
+var filenames = map[string]string{
+	"dockerfile":  "docker",
+	"makefile":    "makefile",
+	"gnumakefile": "makefile",
+}
+
+var (
+	byName      = map[string]Format{}
+	byExtension = map[string]Format{}
+)
+
+func init() {
+	for _, f := range registry {
+		byName[f.Name] = f
+		for _, alias := range f.Aliases {
+			byName[alias] = f
+		}
+		for _, ext := range f.Extensions {
+			byExtension[ext] = f
+		}
+	}
+}
+
+// All returns every registered format, with plain text first and the rest
+// sorted by name.
+func All() []Format {
+	return registry
+}
+
+// Lookup finds a format by its canonical name or one of its aliases, ignoring
+// case.
+func Lookup(name string) (Format, bool) {
+	f, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+	return f, ok
+}
+
+// FromFilename infers a format from a file name's extension or, for files
+// like Dockerfile and Makefile, the name itself.
+func FromFilename(filename string) (Format, bool) {
+	base := strings.ToLower(path.Base(filename))
+	if name, ok := filenames[base]; ok {
+		return byName[name], true
+	}
+	f, ok := byExtension[path.Ext(base)]
+	return f, ok
+}
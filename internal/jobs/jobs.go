@@ -0,0 +1,189 @@
+// Package jobs runs background work from a queue kept in Postgres, so it
+// survives restarts and is shared by every server.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kapilpokhrel/scrolljar/internal/database"
+)
+
+const (
+	// DefaultMaxAttempts is how many times a job is run before it is dead.
+	DefaultMaxAttempts = 5
+
+	pollInterval = 5 * time.Second
+	batchSize    = 10
+	// A job that runs longer than its timeout is given up on, and the lease
+	// outlasts the timeout so no other worker picks the job up meanwhile.
+	jobTimeout   = time.Minute
+	jobLease     = 5 * time.Minute
+	retryBackoff = 10 * time.Second
+	maxBackoff   = time.Hour
+)
+
+// Kind names a type of job whose payload is a T.
+type Kind[T any] string
+
+// Job makes the row that queues a job, for inserting in the transaction that
+// makes the change the job is for.
+func (k Kind[T]) Job(payload T) (database.InsertJobParams, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return database.InsertJobParams{}, err
+	}
+	return database.InsertJobParams{
+		Kind:        string(k),
+		Payload:     data,
+		MaxAttempts: DefaultMaxAttempts,
+	}, nil
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a job's error as one that retrying won't fix, so the job
+// is dead right away.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// Queue runs the jobs of the kinds it has handlers for.
+type Queue struct {
+	store    *database.Store
+	logger   *slog.Logger
+	handlers map[string]func(context.Context, []byte) error
+	wake     chan struct{}
+}
+
+func NewQueue(store *database.Store, logger *slog.Logger) *Queue {
+	return &Queue{
+		store:    store,
+		logger:   logger,
+		handlers: make(map[string]func(context.Context, []byte) error),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Handle sets the function that runs jobs of a kind. A job whose function
+// returns an error is retried with exponential backoff. Handlers must be set
+// before the queue runs.
+func Handle[T any](q *Queue, kind Kind[T], fn func(ctx context.Context, payload T) error) {
+	q.handlers[string(kind)] = func(ctx context.Context, data []byte) error {
+		var payload T
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return Permanent(err)
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// Wake has the queue look for jobs right away instead of at its next poll.
+func (q *Queue) Wake() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run works through the queue until ctx is done, then waits for the jobs
+// that are running to finish. Jobs of a worker that dies are run again
+// once their lease runs out, so handlers must cope with running twice.
+func (q *Queue) Run(ctx context.Context) {
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+
+	var running sync.WaitGroup
+	defer running.Wait()
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	for {
+		for ctx.Err() == nil {
+			jobs, err := q.store.ClaimJobs(ctx, database.ClaimJobsParams{
+				Lease:   pgtype.Interval{Microseconds: jobLease.Microseconds(), Valid: true},
+				Kinds:   kinds,
+				MaxJobs: batchSize,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					q.logger.Error(err.Error())
+				}
+				break
+			}
+			for _, job := range jobs {
+				running.Go(func() { q.run(job) })
+			}
+			if len(jobs) < batchSize {
+				break
+			}
+		}
+		select {
+		case <-poll.C:
+		case <-q.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run runs a job and records how it went. Jobs get to finish while the
+// queue is shutting down rather than being cut off.
+func (q *Queue) run(job database.ClaimJobsRow) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	err := q.handle(ctx, job)
+	if err == nil {
+		if err := q.store.DeleteJob(ctx, job.ID); err != nil {
+			q.logger.Error(err.Error(), "job", job.ID, "kind", job.Kind)
+		}
+		return
+	}
+
+	var permanent permanentError
+	dead := errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts
+	if dead {
+		q.logger.Error(err.Error(), "job", job.ID, "kind", job.Kind, "attempts", job.Attempts)
+	}
+	if err := q.store.SetJobFailed(ctx, database.SetJobFailedParams{
+		Dead:      dead,
+		RunAt:     pgtype.Timestamptz{Time: time.Now().Add(Backoff(job.Attempts, retryBackoff, maxBackoff)), Valid: true},
+		LastError: pgtype.Text{String: err.Error(), Valid: true},
+		ID:        job.ID,
+	}); err != nil {
+		q.logger.Error(err.Error(), "job", job.ID, "kind", job.Kind)
+	}
+}
+
+func (q *Queue) handle(ctx context.Context, job database.ClaimJobsRow) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return q.handlers[job.Kind](ctx, job.Payload)
+}
+
+// Backoff is how long to wait before retrying work that has failed the given
+// number of times: base after the first failure, doubling with each one
+// after that up to max.
+func Backoff(attempts int32, base, max time.Duration) time.Duration {
+	d := base
+	for i := int32(1); i < attempts && d < max; i++ {
+		d *= 2
+	}
+	return min(d, max)
+}
@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts  int32
+		base, max time.Duration
+		want      time.Duration
+	}{
+		{0, time.Minute, time.Hour, time.Minute},
+		{1, time.Minute, time.Hour, time.Minute},
+		{2, time.Minute, time.Hour, 2 * time.Minute},
+		{3, time.Minute, time.Hour, 4 * time.Minute},
+		{6, time.Minute, time.Hour, 32 * time.Minute},
+		{7, time.Minute, time.Hour, time.Hour},
+		{8, time.Minute, time.Hour, time.Hour},
+		{1000, time.Minute, time.Hour, time.Hour},
+		{1, 2 * time.Hour, time.Hour, time.Hour},
+		{14, 5 * time.Minute, 6 * time.Hour, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts, tt.base, tt.max); got != tt.want {
+			t.Errorf("Backoff(%d, %v, %v) = %v, want %v", tt.attempts, tt.base, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package render
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// Cache is a least recently used cache of rendered output, bounded by the
+// total size of the entries it holds.
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	html []byte
+}
+
+func NewCache(maxBytes int) *Cache {
+	return &Cache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// CacheKey identifies a rendering by the hash of the content and everything
+// else that affects the output, so scrolls with the same content share an
+// entry and edited scrolls miss.
+func CacheKey(content, format string, opts Options) string {
+	sum := sha256.Sum256([]byte(content))
+	return fmt.Sprintf("%s/%s/%s/%t/%t/%d-%d", hex.EncodeToString(sum[:]), format, opts.Theme, opts.Inline, opts.Markdown, opts.Lines[0], opts.Lines[1])
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).html, true
+}
+
+// Add stores html under key, evicting the least recently used entries to make
+// room. Output larger than the whole cache isn't stored.
+func (c *Cache) Add(key string, html []byte) {
+	if len(html) > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, html: html})
+	c.size += len(html)
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		entry := c.order.Remove(oldest).(*cacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= len(entry.html)
+	}
+}
@@ -0,0 +1,96 @@
+package render
+
+import "testing"
+
+func TestCache(t *testing.T) {
+	type op struct {
+		add  string // key to add, with a value of size bytes
+		size int
+		get  string // key to get
+	}
+	tests := []struct {
+		name     string
+		maxBytes int
+		ops      []op
+		present  []string
+		missing  []string
+		wantSize int
+	}{
+		{
+			name:     "fits",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 4}, {add: "b", size: 6}},
+			present:  []string{"a", "b"},
+			wantSize: 10,
+		},
+		{
+			name:     "evicts least recently added",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 4}, {add: "b", size: 4}, {add: "c", size: 4}},
+			present:  []string{"b", "c"},
+			missing:  []string{"a"},
+			wantSize: 8,
+		},
+		{
+			name:     "get refreshes recency",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 4}, {add: "b", size: 4}, {get: "a"}, {add: "c", size: 4}},
+			present:  []string{"a", "c"},
+			missing:  []string{"b"},
+			wantSize: 8,
+		},
+		{
+			name:     "evicts several to make room",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 3}, {add: "b", size: 3}, {add: "c", size: 3}, {add: "d", size: 9}},
+			present:  []string{"d"},
+			missing:  []string{"a", "b", "c"},
+			wantSize: 9,
+		},
+		{
+			name:     "larger than the cache",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 4}, {add: "big", size: 11}},
+			present:  []string{"a"},
+			missing:  []string{"big"},
+			wantSize: 4,
+		},
+		{
+			name:     "duplicate add",
+			maxBytes: 10,
+			ops:      []op{{add: "a", size: 4}, {add: "b", size: 4}, {add: "a", size: 4}, {add: "c", size: 4}},
+			present:  []string{"a", "c"},
+			missing:  []string{"b"},
+			wantSize: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(tt.maxBytes)
+			for _, o := range tt.ops {
+				if o.add != "" {
+					c.Add(o.add, make([]byte, o.size))
+				} else {
+					c.Get(o.get)
+				}
+			}
+			// Check what's missing first, since Get changes the order.
+			for _, key := range tt.missing {
+				if _, ok := c.Get(key); ok {
+					t.Errorf("%q is cached, want it evicted", key)
+				}
+			}
+			for _, key := range tt.present {
+				if _, ok := c.Get(key); !ok {
+					t.Errorf("%q isn't cached", key)
+				}
+			}
+			if c.size != tt.wantSize {
+				t.Errorf("size = %d, want %d", c.size, tt.wantSize)
+			}
+			if len(c.entries) != c.order.Len() {
+				t.Errorf("%d entries but %d in the list", len(c.entries), c.order.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+)
+
+// policy strips everything but the markup Markdown and the highlighter
+// produce. Raw HTML in the source is already dropped by goldmark; this guards
+// against anything that slips through, such as javascript: links.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// Highlighted code blocks, styled by class or inline.
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w -]+$`)).OnElements("pre", "code", "span")
+	p.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").OnElements("pre", "span")
+	// Task list items.
+	p.AllowElements("input")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	// Table column alignment.
+	p.AllowStyles("text-align").OnElements("th", "td")
+	return p
+}
+
+// Markdown writes content rendered from GitHub flavored Markdown to HTML,
+// with fenced code blocks highlighted. The output is sanitized, so it is safe
+// to embed even if the content was written to attack whoever views it.
+// Unless opts.Inline is set, the output starts with a <style> element for the
+// highlighted code.
+func Markdown(w io.Writer, content string, opts Options) error {
+	theme := opts.Theme
+	if theme == "" {
+		theme = DefaultTheme
+	}
+	md := goldmark.New(goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithStyle(theme),
+			highlighting.WithFormatOptions(chromahtml.WithClasses(!opts.Inline)),
+		),
+	))
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(content), &buf); err != nil {
+		return err
+	}
+	if !opts.Inline {
+		if err := writeStylesheet(w, chromahtml.New(chromahtml.WithClasses(true)), theme); err != nil {
+			return err
+		}
+	}
+	return policy.SanitizeReaderToWriter(&buf, w)
+}
+
+func writeStylesheet(w io.Writer, formatter *chromahtml.Formatter, theme string) error {
+	if _, err := io.WriteString(w, "<style>"); err != nil {
+		return err
+	}
+	if err := formatter.WriteCSS(w, styles.Get(theme)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</style>")
+	return err
+}
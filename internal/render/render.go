@@ -0,0 +1,82 @@
+// Package render turns scroll content into HTML for clients that don't want
+// to highlight it themselves.
+package render
+
+import (
+	"io"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// DefaultTheme is the theme used when none is asked for.
+const DefaultTheme = "github"
+
+// LinePrefix prefixes the anchor ID of every line, so line 40 is #L40.
+const LinePrefix = "L"
+
+type Options struct {
+	// Theme is the name of a chroma style.
+	Theme string
+	// Inline writes styles into every element instead of emitting a
+	// stylesheet for the CSS classes.
+	Inline bool
+	// Markdown renders Markdown content to HTML rather than highlighting
+	// its source.
+	Markdown bool
+	// Lines is an inclusive range of lines to highlight, if any.
+	Lines [2]int
+}
+
+// HasTheme reports whether name is a known theme.
+func HasTheme(name string) bool {
+	_, ok := styles.Registry[name]
+	return ok
+}
+
+// Render writes content as HTML, either rendered from Markdown or
+// highlighted, depending on opts.Markdown.
+func Render(w io.Writer, content, format string, opts Options) error {
+	if opts.Markdown {
+		return Markdown(w, content, opts)
+	}
+	return Highlight(w, content, format, opts)
+}
+
+// Highlight writes content as syntax-highlighted HTML with linkable line
+// numbers. format is a scroll format name; content in an unknown format is
+// rendered as plain text. Unless opts.Inline is set, the output starts with a
+// <style> element for the classes it uses.
+func Highlight(w io.Writer, content, format string, opts Options) error {
+	lexer := lexers.Get(format)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	theme := opts.Theme
+	if theme == "" {
+		theme = DefaultTheme
+	}
+	style := styles.Get(theme)
+
+	formatter := html.New(
+		html.WithClasses(!opts.Inline),
+		html.WithLineNumbers(true),
+		html.WithLinkableLineNumbers(true, LinePrefix),
+		html.TabWidth(4),
+		html.HighlightLines([][2]int{opts.Lines}),
+	)
+	iterator, err := lexer.Tokenise(nil, content)
+	if err != nil {
+		return err
+	}
+	if !opts.Inline {
+		if err := writeStylesheet(w, formatter, theme); err != nil {
+			return err
+		}
+	}
+	return formatter.Format(w, style, iterator)
+}
@@ -26,11 +26,73 @@ const (
 	AccessPublic  JarAccess = 0
 )
 
+// Defines values for JarEventType.
+const (
+	JarEventJarDeleted     JarEventType = "jar.deleted"
+	JarEventJarExpired     JarEventType = "jar.expired"
+	JarEventJarUpdated     JarEventType = "jar.updated"
+	JarEventScrollCreated  JarEventType = "scroll.created"
+	JarEventScrollDeleted  JarEventType = "scroll.deleted"
+	JarEventScrollRestored JarEventType = "scroll.restored"
+	JarEventScrollUpdated  JarEventType = "scroll.updated"
+	JarEventScrollUploaded JarEventType = "scroll.uploaded"
+)
+
+// Defines values for JarRole.
+const (
+	RoleEditor JarRole = "editor"
+	RoleViewer JarRole = "viewer"
+)
+
+// Defines values for SortOrder.
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for GetJarScrollsParamsSort.
+const (
+	GetJarScrollsParamsSortCreatedAt GetJarScrollsParamsSort = "created_at"
+	GetJarScrollsParamsSortName      GetJarScrollsParamsSort = "name"
+	GetJarScrollsParamsSortPosition  GetJarScrollsParamsSort = "position"
+	GetJarScrollsParamsSortUpdatedAt GetJarScrollsParamsSort = "updated_at"
+)
+
+// Defines values for RenderScrollParamsStyle.
+const (
+	RenderStyleClasses RenderScrollParamsStyle = "classes"
+	RenderStyleInline  RenderScrollParamsStyle = "inline"
+)
+
+// Defines values for GetUserJarsParamsSort.
+const (
+	GetUserJarsParamsSortCreatedAt GetUserJarsParamsSort = "created_at"
+	GetUserJarsParamsSortExpiresAt GetUserJarsParamsSort = "expires_at"
+	GetUserJarsParamsSortName      GetUserJarsParamsSort = "name"
+	GetUserJarsParamsSortUpdatedAt GetUserJarsParamsSort = "updated_at"
+)
+
 // ActivationInput defines model for ActivationInput.
 type ActivationInput struct {
 	Token string `json:"token"`
 }
 
+// AddJarMemberInput Exactly one of email or username identifies the user
+type AddJarMemberInput struct {
+	Email openapi_types.Email `json:"email,omitempty"`
+
+	// Role Viewers can read a private jar without its password, editors can also add and update scrolls
+	Role     JarRole `json:"role"`
+	Username string  `json:"username,omitempty"`
+}
+
 // AuthTokens defines model for AuthTokens.
 type AuthTokens struct {
 	Authorization Token `json:"authorization"`
@@ -53,8 +115,35 @@ type CreateJarOutput struct {
 	Scrolls []CreateScrollOutput `json:"scrolls"`
 }
 
+// CreateJarTransferInput Exactly one of email or username identifies the new owner
+type CreateJarTransferInput struct {
+	Email    openapi_types.Email `json:"email,omitempty"`
+	Username string              `json:"username,omitempty"`
+}
+
+// CreateScrollCommentInput defines model for CreateScrollCommentInput.
+type CreateScrollCommentInput struct {
+	Body string `json:"body"`
+
+	// LineEnd Last line the comment is about, line_start if not given
+	LineEnd int32 `json:"line_end,omitempty"`
+
+	// LineStart First line the comment is about
+	LineStart int32 `json:"line_start"`
+
+	// Revision Revision of the scroll the lines refer to, the current one if not given
+	Revision int32 `json:"revision,omitempty"`
+}
+
 // CreateScrollInput defines model for CreateScrollInput.
 type CreateScrollInput struct {
+	// Appendable Take the content in chunks through /scroll/{id}/append until the scroll is sealed, instead of a single upload
+	Appendable bool `json:"appendable,omitempty"`
+
+	// Filename Name of the file the scroll holds, used to infer format when it isn't given
+	Filename string `json:"filename,omitempty"`
+
+	// Format Name or alias of a format listed by /formats
 	Format string `json:"format,omitempty"`
 	Title  string `json:"title,omitempty"`
 }
@@ -65,6 +154,39 @@ type CreateScrollOutput struct {
 	UploadToken string `json:"upload_token"`
 }
 
+// CreateShareLinkInput defines model for CreateShareLinkInput.
+type CreateShareLinkInput struct {
+	// Expiry How long the link stays valid, defaults to 24h
+	Expiry ExpiryDuration `json:"expiry,omitempty"`
+
+	// MaxUses How many requests the link can authorize; every request made with it counts as a use, so viewing a jar and its scrolls takes several
+	MaxUses *int32 `json:"max_uses,omitempty"`
+}
+
+// CreateShareLinkOutput defines model for CreateShareLinkOutput.
+type CreateShareLinkOutput struct {
+	Link ShareLink `json:"link"`
+
+	// Token Secret sent in the X-Share-Token header; it is only shown once
+	Token string `json:"token"`
+
+	// URI The jar's URI with the token in its share query parameter, which works in place of the header
+	URI string `json:"uri"`
+}
+
+// CreateWebhookInput defines model for CreateWebhookInput.
+type CreateWebhookInput struct {
+	// Events Events to send, or every event when empty
+	Events []JarEventType `json:"events,omitempty"`
+
+	// JarID Jar to send the events of, which the user must own; without one, the events of every jar the user owns are sent
+	JarID string `json:"jarid,omitempty"`
+
+	// Secret Key deliveries are signed with, from 16 to 256 characters
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Error string `json:"error,omitempty"`
@@ -79,22 +201,104 @@ type FieldError struct {
 	Msg string `json:"msg"`
 }
 
+// Format defines model for Format.
+type Format struct {
+	Aliases    []string `json:"aliases"`
+	Extensions []string `json:"extensions"`
+	MimeType   string   `json:"mime_type"`
+	Name       string   `json:"name"`
+}
+
+// FormatList defines model for FormatList.
+type FormatList struct {
+	Formats []Format `json:"formats"`
+}
+
 // Jar defines model for Jar.
 type Jar struct {
-	Access    JarAccess                `json:"access"`
-	CreatedAt pgtype.Timestamptz       `json:"created_at"`
-	ExpiresAt pgtype.Timestamptz       `json:"expires_at"`
-	ID        string                   `json:"id"`
-	Name      string                   `json:"name,omitempty"`
+	Access    JarAccess          `json:"access"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+
+	// Description The jar's README rendered from Markdown to sanitized HTML, only included when fetching a single jar that has one
+	Description string             `json:"description,omitempty"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+
+	// ForkCount Number of live forks of the jar, only included when fetching a single jar
+	ForkCount *int64 `json:"fork_count,omitempty"`
+
+	// ForkedFrom ID of the jar this jar was forked from
+	ForkedFrom string `json:"forked_from,omitempty"`
+	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+
+	// Slug Custom name the jar can be fetched by instead of its ID
+	Slug      string                   `json:"slug,omitempty"`
 	Tags      pgtype.FlatArray[string] `json:"tags"`
+	UpdatedAt pgtype.Timestamptz       `json:"updated_at"`
 	URI       string                   `json:"uri"`
 }
 
 // JarAccess defines model for JarAccess.
 type JarAccess int
 
-// JarCollection defines model for JarCollection.
-type JarCollection = []Jar
+// JarEvent defines model for JarEvent.
+type JarEvent struct {
+	JarID string `json:"jar_id"`
+
+	// ScrollID The scroll the event is about, absent for jar events
+	ScrollID string       `json:"scroll_id,omitempty"`
+	Type     JarEventType `json:"type"`
+}
+
+// JarEventType defines model for JarEventType.
+type JarEventType string
+
+// JarMember defines model for JarMember.
+type JarMember struct {
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+
+	// Role Viewers can read a private jar without its password, editors can also add and update scrolls
+	Role     JarRole `json:"role"`
+	UserID   int64   `json:"user_id"`
+	Username string  `json:"username"`
+}
+
+// JarMemberCollection defines model for JarMemberCollection.
+type JarMemberCollection = []JarMember
+
+// JarPage defines model for JarPage.
+type JarPage struct {
+	Items []Jar `json:"items"`
+
+	// NextCursor Cursor for the next page, absent on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int64  `json:"total"`
+}
+
+// JarRole Viewers can read a private jar without its password, editors can also add and update scrolls
+type JarRole string
+
+// JarTransfer defines model for JarTransfer.
+type JarTransfer struct {
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	FromUserID   int64              `json:"from_user_id"`
+	FromUsername string             `json:"from_username"`
+	ID           int64              `json:"id"`
+	JarName      string             `json:"jar_name,omitempty"`
+	JarID        string             `json:"jarid"`
+	ToUserID     int64              `json:"to_user_id"`
+	ToUsername   string             `json:"to_username"`
+}
+
+// JarTransferList defines model for JarTransferList.
+type JarTransferList struct {
+	// Incoming Transfers offered to the user
+	Incoming []JarTransfer `json:"incoming"`
+
+	// Outgoing Transfers the user started
+	Outgoing []JarTransfer `json:"outgoing"`
+}
 
 // LoginInput defines model for LoginInput.
 type LoginInput struct {
@@ -122,37 +326,190 @@ type RegistrationInput struct {
 	Username string              `json:"username"`
 }
 
+// ReorderJarScrollsInput defines model for ReorderJarScrollsInput.
+type ReorderJarScrollsInput struct {
+	// ScrollIds IDs of the jar's scrolls in their new order
+	ScrollIds []string `json:"scroll_ids"`
+
+	// UpdatedAt updated_at of the jar as last fetched
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Scroll defines model for Scroll.
 type Scroll struct {
+	// Appendable Whether the scroll's content is appended in chunks instead of uploaded at once
+	Appendable bool               `json:"appendable,omitempty"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	Filename   string             `json:"filename,omitempty"`
+	Format     string             `json:"format,omitempty"`
+
+	// FormatConfidence How sure the server is of a format it guessed from the scroll's content, between 0 and 1; absent when the format wasn't guessed
+	FormatConfidence float32 `json:"format_confidence,omitempty"`
+	ID               string  `json:"id"`
+	JarID            string  `json:"jarid"`
+
+	// Position Index of the scroll in its jar's order
+	Position int32 `json:"position"`
+
+	// Readme Whether the scroll is its jar's README
+	Readme bool `json:"readme,omitempty"`
+
+	// Revision Number of times the scroll's content has been written
+	Revision int32 `json:"revision"`
+
+	// Sealed Whether an appendable scroll has been closed to appends
+	Sealed bool   `json:"sealed,omitempty"`
+	Title  string `json:"title,omitempty"`
+	URI    string `json:"uri"`
+}
+
+// ScrollAppend defines model for ScrollAppend.
+type ScrollAppend struct {
+	// Offset Byte offset in the scroll the chunk was appended at
+	Offset int64 `json:"offset"`
+
+	// Size Size of the scroll in bytes after the chunk
+	Size int64 `json:"size"`
+}
+
+// ScrollComment defines model for ScrollComment.
+type ScrollComment struct {
+	Body      string             `json:"body"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	Format    string             `json:"format,omitempty"`
-	ID        string             `json:"id"`
-	JarID     string             `json:"jarid"`
-	Title     string             `json:"title,omitempty"`
-	URI       string             `json:"uri"`
+	ID        int64              `json:"id"`
+	LineEnd   int32              `json:"line_end"`
+	LineStart int32              `json:"line_start"`
+
+	// Outdated Whether the scroll's content has changed since the comment was made, so its lines may have moved
+	Outdated bool `json:"outdated"`
+
+	// Revision Revision of the scroll the comment was made on
+	Revision  int32              `json:"revision"`
+	ScrollID  string             `json:"scroll_id"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+
+	// UserID ID of the author, absent for anonymous comments
+	UserID   int64  `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
 }
 
-// ScrollCollection defines model for ScrollCollection.
-type ScrollCollection = []Scroll
+// ScrollCommentCollection defines model for ScrollCommentCollection.
+type ScrollCommentCollection = []ScrollComment
+
+// ScrollCommentPatchInput defines model for ScrollCommentPatchInput.
+type ScrollCommentPatchInput struct {
+	Body string `json:"body"`
+}
+
+// ScrollDestinationInput defines model for ScrollDestinationInput.
+type ScrollDestinationInput struct {
+	// JarID ID or slug of the destination jar
+	JarID string `json:"jarid"`
+}
 
 // ScrollFetch defines model for ScrollFetch.
 type ScrollFetch struct {
+	// FetchURL Absent while an appendable scroll hasn't been sealed
 	FetchURL string `json:"fetch_url,omitempty"`
 	Scroll   Scroll `json:"scroll,omitempty"`
 }
 
+// ScrollPage defines model for ScrollPage.
+type ScrollPage struct {
+	Items []Scroll `json:"items"`
+
+	// NextCursor Cursor for the next page, absent on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int64  `json:"total"`
+}
+
 // ScrollPatchInput defines model for ScrollPatchInput.
 type ScrollPatchInput struct {
-	Format *string `json:"format,omitempty"`
+	Filename *string `json:"filename,omitempty"`
+	Format   *string `json:"format,omitempty"`
+
+	// Readme Flag the scroll as its jar's README, replacing the jar's current README, or unflag it; only Markdown scrolls can be READMEs
+	Readme *bool   `json:"readme,omitempty"`
 	Title  *string `json:"title,omitempty"`
 }
 
+// SearchHit defines model for SearchHit.
+type SearchHit struct {
+	Rank   float32 `json:"rank"`
+	Scroll Scroll  `json:"scroll"`
+
+	// Snippet HTML-escaped excerpt with matches wrapped in <mark> tags
+	Snippet string `json:"snippet"`
+}
+
+// SearchResults defines model for SearchResults.
+type SearchResults struct {
+	Results []SearchHit `json:"results"`
+	Total   int64       `json:"total"`
+}
+
+// SetJarSlugInput defines model for SetJarSlugInput.
+type SetJarSlugInput struct {
+	// Slug Lowercase letters, digits and hyphens, starting and ending with a letter or digit
+	Slug string `json:"slug"`
+}
+
+// ShareLink defines model for ShareLink.
+type ShareLink struct {
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	ID        int64              `json:"id"`
+	JarID     string             `json:"jarid"`
+
+	// MaxUses Number of requests the link can authorize, absent when unlimited
+	MaxUses int32 `json:"max_uses,omitempty"`
+	Revoked bool  `json:"revoked"`
+	Uses    int32 `json:"uses"`
+}
+
+// ShareLinkCollection defines model for ShareLinkCollection.
+type ShareLinkCollection = []ShareLink
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
+
 // Token defines model for Token.
 type Token struct {
 	Expiry time.Time `json:"expiry"`
 	Token  string    `json:"token"`
 }
 
+// Trash defines model for Trash.
+type Trash struct {
+	Jars []TrashedJar `json:"jars"`
+
+	// Scrolls Scrolls trashed on their own; those of a trashed jar come back with the jar
+	Scrolls []TrashedScroll `json:"scrolls"`
+}
+
+// TrashedJar defines model for TrashedJar.
+type TrashedJar struct {
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	Jar       Jar                `json:"jar"`
+
+	// PurgeAt Time after which the jar can no longer be restored
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// TrashedScroll defines model for TrashedScroll.
+type TrashedScroll struct {
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+
+	// PurgeAt Time after which the scroll can no longer be restored
+	PurgeAt time.Time `json:"purge_at"`
+	Scroll  Scroll    `json:"scroll"`
+}
+
+// UnlockJarInput defines model for UnlockJarInput.
+type UnlockJarInput struct {
+	Password string `json:"password"`
+}
+
 // User defines model for User.
 type User struct {
 	CreatedAt time.Time `json:"created_at"`
@@ -165,18 +522,119 @@ type ValidationError struct {
 	Errors []FieldError `json:"errors,omitempty"`
 }
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	// Active Whether the webhook is sent events; it is disabled after too many failed deliveries in a row
+	Active    bool               `json:"active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+
+	// Events Events the webhook is sent, or every event when empty
+	Events []JarEventType `json:"events"`
+
+	// Failures Number of failed delivery attempts since the last successful one
+	Failures int32 `json:"failures"`
+	ID       int64 `json:"id"`
+
+	// JarID The jar the webhook is for, absent when it is for every jar the user owns
+	JarID string `json:"jarid,omitempty"`
+	URL   string `json:"url"`
+}
+
+// WebhookCollection defines model for WebhookCollection.
+type WebhookCollection = []Webhook
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int32              `json:"attempts"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	DeliveredAt pgtype.Timestamptz `json:"delivered_at,omitempty"`
+	Event       JarEventType       `json:"event"`
+	ID          int64              `json:"id"`
+
+	// LastError Why the last attempt failed
+	LastError string `json:"last_error,omitempty"`
+
+	// NextAttemptAt When a pending delivery is attempted next
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at,omitempty"`
+	Payload       JarEvent           `json:"payload"`
+
+	// ResponseStatus HTTP status of the last attempt's response, absent when there was none
+	ResponseStatus int32                 `json:"response_status,omitempty"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryCollection defines model for WebhookDeliveryCollection.
+type WebhookDeliveryCollection = []WebhookDelivery
+
+// WebhookPatchInput defines model for WebhookPatchInput.
+type WebhookPatchInput struct {
+	// Active Turn the webhook back on, which also clears its failures, or off
+	Active *bool           `json:"active,omitempty"`
+	Events *[]JarEventType `json:"events,omitempty"`
+	Secret *string         `json:"secret,omitempty"`
+	URL    *string         `json:"url,omitempty"`
+}
+
+// CommentID defines model for CommentId.
+type CommentID = int64
+
 // JarID defines model for JarId.
 type JarID = string
 
+// JarToken defines model for JarToken.
+type JarToken = string
+
+// Lines defines model for Lines.
+type Lines = string
+
+// PageCursor defines model for PageCursor.
+type PageCursor = string
+
+// PageLimit defines model for PageLimit.
+type PageLimit = int
+
+// PageOrder defines model for PageOrder.
+type PageOrder = SortOrder
+
+// PastePassword defines model for PastePassword.
+type PastePassword = string
+
 // ScrollID defines model for ScrollId.
 type ScrollID = string
 
+// ShareToken defines model for ShareToken.
+type ShareToken = string
+
+// TransferID defines model for TransferId.
+type TransferID = int64
+
+// UploadToken defines model for UploadToken.
+type UploadToken = string
+
+// UserID defines model for UserId.
+type UserID = int64
+
+// WebhookID defines model for WebhookId.
+type WebhookID = int64
+
+// EditConflict defines model for EditConflict.
+type EditConflict = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // NotFound defines model for NotFound.
 type NotFound = Error
 
 // RateLimitExceeded defines model for RateLimitExceeded.
 type RateLimitExceeded = Error
 
+// SlugTaken defines model for SlugTaken.
+type SlugTaken = Error
+
 // SuccessfulMessage defines model for SuccessfulMessage.
 type SuccessfulMessage = Message
 
@@ -186,13 +644,166 @@ type Unauthorized = Error
 // GetJarParams defines parameters for GetJar.
 type GetJarParams struct {
 	// XPastePassword Optional password for password protected jar
-	XPastePassword string `json:"X-Paste-Password,omitempty"`
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// GetJarEventsParams defines parameters for GetJarEvents.
+type GetJarEventsParams struct {
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// GetJarScrollsParams defines parameters for GetJarScrolls.
+type GetJarScrollsParams struct {
+	// Limit Maximum number of items in a page
+	Limit PageLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from the next_cursor of the previous page
+	Cursor PageCursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Order  PageOrder  `form:"order,omitempty" json:"order,omitempty"`
+
+	// Sort Sorting by position defaults to ascending order.
+	Sort GetJarScrollsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
 }
 
+// GetJarScrollsParamsSort defines parameters for GetJarScrolls.
+type GetJarScrollsParamsSort string
+
 // GetScrollParams defines parameters for GetScroll.
 type GetScrollParams struct {
 	// XPastePassword Optional password for password protected jar
-	XPastePassword string `json:"X-Paste-Password,omitempty"`
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// AppendScrollTextBody defines parameters for AppendScroll.
+type AppendScrollTextBody = string
+
+// AppendScrollParams defines parameters for AppendScroll.
+type AppendScrollParams struct {
+	// XUploadToken Upload token of the scroll
+	XUploadToken UploadToken `json:"X-Upload-Token"`
+}
+
+// GetScrollCommentsParams defines parameters for GetScrollComments.
+type GetScrollCommentsParams struct {
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// CreateScrollCommentParams defines parameters for CreateScrollComment.
+type CreateScrollCommentParams struct {
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// GetScrollRawParams defines parameters for GetScrollRaw.
+type GetScrollRawParams struct {
+	// Lines Range of lines such as 40-60, or a single line such as 40
+	Lines Lines `form:"lines,omitempty" json:"lines,omitempty"`
+
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// RenderScrollParams defines parameters for RenderScroll.
+type RenderScrollParams struct {
+	// Theme Name of the highlighting theme
+	Theme string `form:"theme,omitempty" json:"theme,omitempty"`
+
+	// Style Whether to style elements through CSS classes, with a stylesheet at the start of the output, or inline
+	Style RenderScrollParamsStyle `form:"style,omitempty" json:"style,omitempty"`
+
+	// Source Highlight the source of a markdown scroll instead of rendering it
+	Source bool `form:"source,omitempty" json:"source,omitempty"`
+
+	// Lines Range of lines such as 40-60, or a single line such as 40
+	Lines Lines `form:"lines,omitempty" json:"lines,omitempty"`
+
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+}
+
+// RenderScrollParamsStyle defines parameters for RenderScroll.
+type RenderScrollParamsStyle string
+
+// SealScrollParams defines parameters for SealScroll.
+type SealScrollParams struct {
+	// XUploadToken Upload token of the scroll
+	XUploadToken UploadToken `json:"X-Upload-Token"`
+}
+
+// TailScrollParams defines parameters for TailScroll.
+type TailScrollParams struct {
+	// XPastePassword Optional password for password protected jar
+	XPastePassword PastePassword `json:"X-Paste-Password,omitempty"`
+
+	// XShareToken Optional share link token granting read access to a private jar; it can also be sent in the share query parameter
+	XShareToken ShareToken `json:"X-Share-Token,omitempty"`
+
+	// XJarToken Optional jar token from the unlock route for a private jar
+	XJarToken JarToken `json:"X-Jar-Token,omitempty"`
+
+	// LastEventID ID of the last event received, to resume after it
+	LastEventID string `json:"Last-Event-ID,omitempty"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// Q Search query (websearch syntax, e.g. "quoted phrase" -excluded)
+	Q      string `form:"q" json:"q"`
+	Limit  int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset int    `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
 // UploadScrollParams defines parameters for UploadScroll.
@@ -201,15 +812,77 @@ type UploadScrollParams struct {
 	XUploadToken string `json:"X-Upload-Token"`
 }
 
+// GetUserJarsParams defines parameters for GetUserJars.
+type GetUserJarsParams struct {
+	// Limit Maximum number of items in a page
+	Limit PageLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from the next_cursor of the previous page
+	Cursor PageCursor            `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Order  PageOrder             `form:"order,omitempty" json:"order,omitempty"`
+	Sort   GetUserJarsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Access Only return jars with this access type
+	Access *JarAccess `form:"access,omitempty" json:"access,omitempty"`
+
+	// HasPassword Only return jars with (true) or without (false) a password
+	HasPassword *bool `form:"has_password,omitempty" json:"has_password,omitempty"`
+
+	// ExpiringBefore Only return jars expiring before this time
+	ExpiringBefore *time.Time `form:"expiring_before,omitempty" json:"expiring_before,omitempty"`
+}
+
+// GetUserJarsParamsSort defines parameters for GetUserJars.
+type GetUserJarsParamsSort string
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// Limit Maximum number of items in a page
+	Limit PageLimit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // CreateJarJSONRequestBody defines body for CreateJar for application/json ContentType.
 type CreateJarJSONRequestBody = CreateJarInput
 
+// AddJarMemberJSONRequestBody defines body for AddJarMember for application/json ContentType.
+type AddJarMemberJSONRequestBody = AddJarMemberInput
+
+// ReorderJarScrollsJSONRequestBody defines body for ReorderJarScrolls for application/json ContentType.
+type ReorderJarScrollsJSONRequestBody = ReorderJarScrollsInput
+
+// CreateShareLinkJSONRequestBody defines body for CreateShareLink for application/json ContentType.
+type CreateShareLinkJSONRequestBody = CreateShareLinkInput
+
+// SetJarSlugJSONRequestBody defines body for SetJarSlug for application/json ContentType.
+type SetJarSlugJSONRequestBody = SetJarSlugInput
+
+// CreateJarTransferJSONRequestBody defines body for CreateJarTransfer for application/json ContentType.
+type CreateJarTransferJSONRequestBody = CreateJarTransferInput
+
+// UnlockJarJSONRequestBody defines body for UnlockJar for application/json ContentType.
+type UnlockJarJSONRequestBody = UnlockJarInput
+
 // PatchScrollJSONRequestBody defines body for PatchScroll for application/json ContentType.
 type PatchScrollJSONRequestBody = ScrollPatchInput
 
 // CreateScrollJSONRequestBody defines body for CreateScroll for application/json ContentType.
 type CreateScrollJSONRequestBody = CreateScrollInput
 
+// AppendScrollTextRequestBody defines body for AppendScroll for text/plain ContentType.
+type AppendScrollTextRequestBody = AppendScrollTextBody
+
+// CreateScrollCommentJSONRequestBody defines body for CreateScrollComment for application/json ContentType.
+type CreateScrollCommentJSONRequestBody = CreateScrollCommentInput
+
+// PatchScrollCommentJSONRequestBody defines body for PatchScrollComment for application/json ContentType.
+type PatchScrollCommentJSONRequestBody = ScrollCommentPatchInput
+
+// CopyScrollJSONRequestBody defines body for CopyScroll for application/json ContentType.
+type CopyScrollJSONRequestBody = ScrollDestinationInput
+
+// MoveScrollJSONRequestBody defines body for MoveScroll for application/json ContentType.
+type MoveScrollJSONRequestBody = ScrollDestinationInput
+
 // CreateActivationTokenJSONRequestBody defines body for CreateActivationToken for application/json ContentType.
 type CreateActivationTokenJSONRequestBody = LoginInput
 
@@ -222,24 +895,72 @@ type AuthUserJSONRequestBody = LoginInput
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody = RegistrationInput
 
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookInput
+
+// PatchWebhookJSONRequestBody defines body for PatchWebhook for application/json ContentType.
+type PatchWebhookJSONRequestBody = WebhookPatchInput
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Route to list the scroll formats the server recognizes
+	// (GET /formats)
+	GetFormats(w http.ResponseWriter, r *http.Request)
 	// Route to create a new Jar
 	// (POST /jar)
 	CreateJar(w http.ResponseWriter, r *http.Request)
-	// Route to delete a Jar. Deleting a Jar will all the scrolls within it.
+	// Route to move a Jar and the scrolls within it to the trash
 	// (DELETE /jar/{id})
 	DeleteJar(w http.ResponseWriter, r *http.Request, id JarID)
 	// Route to get a jar information
 	// (GET /jar/{id})
 	GetJar(w http.ResponseWriter, r *http.Request, id JarID, params GetJarParams)
+	// Route to follow changes to a jar and its scrolls
+	// (GET /jar/{id}/events)
+	GetJarEvents(w http.ResponseWriter, r *http.Request, id JarID, params GetJarEventsParams)
+	// Route to fork a public jar into a new jar owned by the user
+	// (POST /jar/{id}/fork)
+	ForkJar(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to list the users a jar is shared with
+	// (GET /jar/{id}/members)
+	GetJarMembers(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to share a jar with a user by email or username, or change their role
+	// (POST /jar/{id}/members)
+	AddJarMember(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to revoke a user's access to a jar. Members can remove themselves.
+	// (DELETE /jar/{id}/members/{user_id})
+	RemoveJarMember(w http.ResponseWriter, r *http.Request, id JarID, userID UserID)
+	// Route to set the order of a jar's scrolls
+	// (PUT /jar/{id}/order)
+	ReorderJarScrolls(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to restore a trashed Jar along with the scrolls trashed with it
+	// (POST /jar/{id}/restore)
+	RestoreJar(w http.ResponseWriter, r *http.Request, id JarID)
 	// Route to get all scrolls of a Jar
 	// (GET /jar/{id}/scrolls)
-	GetJarScrolls(w http.ResponseWriter, r *http.Request, id JarID)
+	GetJarScrolls(w http.ResponseWriter, r *http.Request, id JarID, params GetJarScrollsParams)
+	// Route to list the share links of a jar
+	// (GET /jar/{id}/share-links)
+	GetShareLinks(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to create an expiring link granting read access to a private jar without its password
+	// (POST /jar/{id}/share-links)
+	CreateShareLink(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to revoke a share link
+	// (DELETE /jar/{id}/share-links/{link_id})
+	RevokeShareLink(w http.ResponseWriter, r *http.Request, id JarID, linkID int64)
+	// Route to set a custom slug the jar can be fetched by
+	// (PUT /jar/{id}/slug)
+	SetJarSlug(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to offer ownership of a jar to another user
+	// (POST /jar/{id}/transfer)
+	CreateJarTransfer(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to trade a private jar's password for a short-lived jar token
+	// (POST /jar/{id}/unlock)
+	UnlockJar(w http.ResponseWriter, r *http.Request, id JarID)
 	// Ping to get health of server.
 	// (GET /ping)
 	Ping(w http.ResponseWriter, r *http.Request)
-	// Route to delete a scroll
+	// Route to move a scroll to the trash
 	// (DELETE /scroll/{id})
 	DeleteScroll(w http.ResponseWriter, r *http.Request, id ScrollID)
 	// Route to get a scroll information
@@ -251,9 +972,54 @@ type ServerInterface interface {
 	// Route to create a new Scroll
 	// (POST /scroll/{id})
 	CreateScroll(w http.ResponseWriter, r *http.Request, id JarID)
+	// Route to append a chunk of content to an appendable scroll
+	// (POST /scroll/{id}/append)
+	AppendScroll(w http.ResponseWriter, r *http.Request, id ScrollID, params AppendScrollParams)
+	// Route to list the comments on a scroll, in line order
+	// (GET /scroll/{id}/comments)
+	GetScrollComments(w http.ResponseWriter, r *http.Request, id ScrollID, params GetScrollCommentsParams)
+	// Route to comment on a range of a scroll's lines
+	// (POST /scroll/{id}/comments)
+	CreateScrollComment(w http.ResponseWriter, r *http.Request, id ScrollID, params CreateScrollCommentParams)
+	// Route to delete a comment. Its author and the jar's owner can delete it.
+	// (DELETE /scroll/{id}/comments/{comment_id})
+	DeleteScrollComment(w http.ResponseWriter, r *http.Request, id ScrollID, commentID CommentID)
+	// Route to edit a comment. Only its author can edit it.
+	// (PATCH /scroll/{id}/comments/{comment_id})
+	PatchScrollComment(w http.ResponseWriter, r *http.Request, id ScrollID, commentID CommentID)
+	// Route to copy an uploaded scroll into another jar
+	// (POST /scroll/{id}/copy)
+	CopyScroll(w http.ResponseWriter, r *http.Request, id ScrollID)
+	// Route to move an uploaded scroll into another jar
+	// (POST /scroll/{id}/move)
+	MoveScroll(w http.ResponseWriter, r *http.Request, id ScrollID)
+	// Route to get the content of a scroll
+	// (GET /scroll/{id}/raw)
+	GetScrollRaw(w http.ResponseWriter, r *http.Request, id ScrollID, params GetScrollRawParams)
+	// Route to get a scroll as syntax-highlighted HTML
+	// (GET /scroll/{id}/render)
+	RenderScroll(w http.ResponseWriter, r *http.Request, id ScrollID, params RenderScrollParams)
+	// Route to restore a trashed scroll of a live Jar
+	// (POST /scroll/{id}/restore)
+	RestoreScroll(w http.ResponseWriter, r *http.Request, id ScrollID)
+	// Route to close an appendable scroll to further appends
+	// (POST /scroll/{id}/seal)
+	SealScroll(w http.ResponseWriter, r *http.Request, id ScrollID, params SealScrollParams)
+	// Route to follow the content of an appendable scroll as it is appended
+	// (GET /scroll/{id}/tail)
+	TailScroll(w http.ResponseWriter, r *http.Request, id ScrollID, params TailScrollParams)
+	// Route to search the contents of public jars and jars the user owns or is a member of
+	// (GET /search)
+	Search(w http.ResponseWriter, r *http.Request, params SearchParams)
 	// Route to get a activation token of a user
 	// (POST /token/activation)
 	CreateActivationToken(w http.ResponseWriter, r *http.Request)
+	// Route for the owner to cancel, or the target to decline, a pending jar transfer
+	// (DELETE /transfer/{transfer_id})
+	DeleteJarTransfer(w http.ResponseWriter, r *http.Request, transferID TransferID)
+	// Route to accept a jar transfer offered to the user
+	// (POST /transfer/{transfer_id}/accept)
+	AcceptJarTransfer(w http.ResponseWriter, r *http.Request, transferID TransferID)
 	// Route to upload the scroll content
 	// (PUT /upload)
 	UploadScroll(w http.ResponseWriter, r *http.Request, params UploadScrollParams)
@@ -268,10 +1034,31 @@ type ServerInterface interface {
 	AuthUser(w http.ResponseWriter, r *http.Request)
 	// Route to get list of jars creatd by the user
 	// (GET /user/jars)
-	GetUserJars(w http.ResponseWriter, r *http.Request)
+	GetUserJars(w http.ResponseWriter, r *http.Request, params GetUserJarsParams)
 	// Route to create a new User
 	// (POST /user/register)
 	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Route to list the pending jar transfers started by or offered to the user
+	// (GET /user/transfers)
+	GetUserTransfers(w http.ResponseWriter, r *http.Request)
+	// Route to list the jars and scrolls in the user's trash
+	// (GET /user/trash)
+	GetUserTrash(w http.ResponseWriter, r *http.Request)
+	// Route to list the user's webhooks
+	// (GET /user/webhooks)
+	GetUserWebhooks(w http.ResponseWriter, r *http.Request)
+	// Route to register a webhook for a jar's events, or for those of every jar the user owns
+	// (POST /webhook)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Route to delete a webhook along with its deliveries
+	// (DELETE /webhook/{webhook_id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookID WebhookID)
+	// Route to change a webhook, or to turn a disabled one back on
+	// (PATCH /webhook/{webhook_id})
+	PatchWebhook(w http.ResponseWriter, r *http.Request, webhookID WebhookID)
+	// Route to list a webhook's latest deliveries, newest first
+	// (GET /webhook/{webhook_id}/deliveries)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookID WebhookID, params GetWebhookDeliveriesParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -283,6 +1070,20 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetFormats operation middleware
+func (siw *ServerInterfaceWrapper) GetFormats(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFormats(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // CreateJar operation middleware
 func (siw *ServerInterfaceWrapper) CreateJar(w http.ResponseWriter, r *http.Request) {
 
@@ -349,7 +1150,7 @@ func (siw *ServerInterfaceWrapper) GetJar(w http.ResponseWriter, r *http.Request
 
 	// ------------- Optional header parameter "X-Paste-Password" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
-		var XPastePassword string
+		var XPastePassword PastePassword
 		n := len(valueList)
 		if n != 1 {
 			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
@@ -366,19 +1167,57 @@ func (siw *ServerInterfaceWrapper) GetJar(w http.ResponseWriter, r *http.Request
 
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetJar(w, r, id, params)
-	}))
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJar(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
 	handler.ServeHTTP(w, r)
 }
 
-// GetJarScrolls operation middleware
-func (siw *ServerInterfaceWrapper) GetJarScrolls(w http.ResponseWriter, r *http.Request) {
+// GetJarEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetJarEvents(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -391,8 +1230,70 @@ func (siw *ServerInterfaceWrapper) GetJarScrolls(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetJarEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetJarScrolls(w, r, id)
+		siw.Handler.GetJarEvents(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -402,11 +1303,28 @@ func (siw *ServerInterfaceWrapper) GetJarScrolls(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// Ping operation middleware
-func (siw *ServerInterfaceWrapper) Ping(w http.ResponseWriter, r *http.Request) {
+// ForkJar operation middleware
+func (siw *ServerInterfaceWrapper) ForkJar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Ping(w, r)
+		siw.Handler.ForkJar(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -416,13 +1334,13 @@ func (siw *ServerInterfaceWrapper) Ping(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteScroll operation middleware
-func (siw *ServerInterfaceWrapper) DeleteScroll(w http.ResponseWriter, r *http.Request) {
+// GetJarMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetJarMembers(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ScrollID
+	var id JarID
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -437,7 +1355,7 @@ func (siw *ServerInterfaceWrapper) DeleteScroll(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteScroll(w, r, id)
+		siw.Handler.GetJarMembers(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -447,13 +1365,13 @@ func (siw *ServerInterfaceWrapper) DeleteScroll(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetScroll operation middleware
-func (siw *ServerInterfaceWrapper) GetScroll(w http.ResponseWriter, r *http.Request) {
+// AddJarMember operation middleware
+func (siw *ServerInterfaceWrapper) AddJarMember(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ScrollID
+	var id JarID
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -461,32 +1379,14 @@ func (siw *ServerInterfaceWrapper) GetScroll(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetScrollParams
-
-	headers := r.Header
-
-	// ------------- Optional header parameter "X-Paste-Password" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
-		var XPastePassword string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
-			return
-		}
-
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
-			return
-		}
+	ctx := r.Context()
 
-		params.XPastePassword = XPastePassword
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	}
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetScroll(w, r, id, params)
+		siw.Handler.AddJarMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -496,13 +1396,13 @@ func (siw *ServerInterfaceWrapper) GetScroll(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// PatchScroll operation middleware
-func (siw *ServerInterfaceWrapper) PatchScroll(w http.ResponseWriter, r *http.Request) {
+// RemoveJarMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveJarMember(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ScrollID
+	var id JarID
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -510,6 +1410,15 @@ func (siw *ServerInterfaceWrapper) PatchScroll(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Path parameter "user_id" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", r.PathValue("user_id"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -517,7 +1426,7 @@ func (siw *ServerInterfaceWrapper) PatchScroll(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchScroll(w, r, id)
+		siw.Handler.RemoveJarMember(w, r, id, userID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -527,8 +1436,8 @@ func (siw *ServerInterfaceWrapper) PatchScroll(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateScroll operation middleware
-func (siw *ServerInterfaceWrapper) CreateScroll(w http.ResponseWriter, r *http.Request) {
+// ReorderJarScrolls operation middleware
+func (siw *ServerInterfaceWrapper) ReorderJarScrolls(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -548,7 +1457,7 @@ func (siw *ServerInterfaceWrapper) CreateScroll(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateScroll(w, r, id)
+		siw.Handler.ReorderJarScrolls(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -558,11 +1467,28 @@ func (siw *ServerInterfaceWrapper) CreateScroll(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateActivationToken operation middleware
-func (siw *ServerInterfaceWrapper) CreateActivationToken(w http.ResponseWriter, r *http.Request) {
+// RestoreJar operation middleware
+func (siw *ServerInterfaceWrapper) RestoreJar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateActivationToken(w, r)
+		siw.Handler.RestoreJar(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -572,41 +1498,116 @@ func (siw *ServerInterfaceWrapper) CreateActivationToken(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// UploadScroll operation middleware
-func (siw *ServerInterfaceWrapper) UploadScroll(w http.ResponseWriter, r *http.Request) {
+// GetJarScrolls operation middleware
+func (siw *ServerInterfaceWrapper) GetJarScrolls(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params UploadScrollParams
+	var params GetJarScrollsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
 	headers := r.Header
 
-	// ------------- Required header parameter "X-Upload-Token" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Upload-Token")]; found {
-		var XUploadToken string
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
 		n := len(valueList)
 		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Upload-Token", Count: n})
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
 			return
 		}
 
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Upload-Token", valueList[0], &XUploadToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
 		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Upload-Token", Err: err})
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
 			return
 		}
 
-		params.XUploadToken = XUploadToken
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
 
-	} else {
-		err := fmt.Errorf("Header parameter X-Upload-Token is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Upload-Token", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadScroll(w, r, params)
+		siw.Handler.GetJarScrolls(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -616,8 +1617,19 @@ func (siw *ServerInterfaceWrapper) UploadScroll(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// GetShareLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetShareLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -626,7 +1638,7 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r)
+		siw.Handler.GetShareLinks(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -636,18 +1648,1333 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// ActivateUser operation middleware
-func (siw *ServerInterfaceWrapper) ActivateUser(w http.ResponseWriter, r *http.Request) {
+// CreateShareLink operation middleware
+func (siw *ServerInterfaceWrapper) CreateShareLink(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ActivateUser(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ------------- Path parameter "id" -------------
+	var id JarID
 
-	handler.ServeHTTP(w, r)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateShareLink(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeShareLink operation middleware
+func (siw *ServerInterfaceWrapper) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "link_id" -------------
+	var linkID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "link_id", r.PathValue("link_id"), &linkID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "link_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeShareLink(w, r, id, linkID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetJarSlug operation middleware
+func (siw *ServerInterfaceWrapper) SetJarSlug(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetJarSlug(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateJarTransfer operation middleware
+func (siw *ServerInterfaceWrapper) CreateJarTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateJarTransfer(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnlockJar operation middleware
+func (siw *ServerInterfaceWrapper) UnlockJar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnlockJar(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Ping operation middleware
+func (siw *ServerInterfaceWrapper) Ping(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Ping(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteScroll operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScroll operation middleware
+func (siw *ServerInterfaceWrapper) GetScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScrollParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScroll(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchScroll operation middleware
+func (siw *ServerInterfaceWrapper) PatchScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateScroll operation middleware
+func (siw *ServerInterfaceWrapper) CreateScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id JarID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AppendScroll operation middleware
+func (siw *ServerInterfaceWrapper) AppendScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AppendScrollParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Upload-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Upload-Token")]; found {
+		var XUploadToken UploadToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Upload-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Upload-Token", valueList[0], &XUploadToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Upload-Token", Err: err})
+			return
+		}
+
+		params.XUploadToken = XUploadToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Upload-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Upload-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AppendScroll(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScrollComments operation middleware
+func (siw *ServerInterfaceWrapper) GetScrollComments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScrollCommentsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScrollComments(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateScrollComment operation middleware
+func (siw *ServerInterfaceWrapper) CreateScrollComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateScrollCommentParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateScrollComment(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteScrollComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScrollComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentID CommentID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "comment_id", r.PathValue("comment_id"), &commentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "comment_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteScrollComment(w, r, id, commentID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchScrollComment operation middleware
+func (siw *ServerInterfaceWrapper) PatchScrollComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentID CommentID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "comment_id", r.PathValue("comment_id"), &commentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "comment_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchScrollComment(w, r, id, commentID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CopyScroll operation middleware
+func (siw *ServerInterfaceWrapper) CopyScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CopyScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MoveScroll operation middleware
+func (siw *ServerInterfaceWrapper) MoveScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MoveScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScrollRaw operation middleware
+func (siw *ServerInterfaceWrapper) GetScrollRaw(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScrollRawParams
+
+	// ------------- Optional query parameter "lines" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lines", r.URL.Query(), &params.Lines)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lines", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScrollRaw(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenderScroll operation middleware
+func (siw *ServerInterfaceWrapper) RenderScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderScrollParams
+
+	// ------------- Optional query parameter "theme" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "theme", r.URL.Query(), &params.Theme)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "theme", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "style" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "style", r.URL.Query(), &params.Style)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "style", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "lines" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lines", r.URL.Query(), &params.Lines)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lines", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenderScroll(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreScroll operation middleware
+func (siw *ServerInterfaceWrapper) RestoreScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreScroll(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SealScroll operation middleware
+func (siw *ServerInterfaceWrapper) SealScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SealScrollParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Upload-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Upload-Token")]; found {
+		var XUploadToken UploadToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Upload-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Upload-Token", valueList[0], &XUploadToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Upload-Token", Err: err})
+			return
+		}
+
+		params.XUploadToken = XUploadToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Upload-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Upload-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SealScroll(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TailScroll operation middleware
+func (siw *ServerInterfaceWrapper) TailScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ScrollID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TailScrollParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Paste-Password" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Paste-Password")]; found {
+		var XPastePassword PastePassword
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Paste-Password", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Paste-Password", valueList[0], &XPastePassword, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Paste-Password", Err: err})
+			return
+		}
+
+		params.XPastePassword = XPastePassword
+
+	}
+
+	// ------------- Optional header parameter "X-Share-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Share-Token")]; found {
+		var XShareToken ShareToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Share-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Share-Token", valueList[0], &XShareToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Share-Token", Err: err})
+			return
+		}
+
+		params.XShareToken = XShareToken
+
+	}
+
+	// ------------- Optional header parameter "X-Jar-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Jar-Token")]; found {
+		var XJarToken JarToken
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Jar-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Jar-Token", valueList[0], &XJarToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Jar-Token", Err: err})
+			return
+		}
+
+		params.XJarToken = XJarToken
+
+	}
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TailScroll(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Search operation middleware
+func (siw *ServerInterfaceWrapper) Search(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Search(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateActivationToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateActivationToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateActivationToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteJarTransfer operation middleware
+func (siw *ServerInterfaceWrapper) DeleteJarTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transfer_id" -------------
+	var transferID TransferID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transfer_id", r.PathValue("transfer_id"), &transferID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transfer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteJarTransfer(w, r, transferID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AcceptJarTransfer operation middleware
+func (siw *ServerInterfaceWrapper) AcceptJarTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "transfer_id" -------------
+	var transferID TransferID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transfer_id", r.PathValue("transfer_id"), &transferID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transfer_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptJarTransfer(w, r, transferID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadScroll operation middleware
+func (siw *ServerInterfaceWrapper) UploadScroll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UploadScrollParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Upload-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Upload-Token")]; found {
+		var XUploadToken string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Upload-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Upload-Token", valueList[0], &XUploadToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Upload-Token", Err: err})
+			return
+		}
+
+		params.XUploadToken = XUploadToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Upload-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Upload-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadScroll(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ActivateUser operation middleware
+func (siw *ServerInterfaceWrapper) ActivateUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ActivateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
 // AuthUser operation middleware
@@ -667,14 +2994,75 @@ func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Reque
 // GetUserJars operation middleware
 func (siw *ServerInterfaceWrapper) GetUserJars(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserJarsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "access" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "access", r.URL.Query(), &params.Access)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "access", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "has_password" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "has_password", r.URL.Query(), &params.HasPassword)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "has_password", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "expiring_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expiring_before", r.URL.Query(), &params.ExpiringBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expiring_before", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserJars(w, r)
+		siw.Handler.GetUserJars(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -698,6 +3086,190 @@ func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// GetUserTransfers operation middleware
+func (siw *ServerInterfaceWrapper) GetUserTransfers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserTransfers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserTrash operation middleware
+func (siw *ServerInterfaceWrapper) GetUserTrash(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserTrash(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetUserWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookID WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", r.PathValue("webhook_id"), &webhookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, webhookID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchWebhook operation middleware
+func (siw *ServerInterfaceWrapper) PatchWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookID WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", r.PathValue("webhook_id"), &webhookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchWebhook(w, r, webhookID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookID WebhookID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhook_id", r.PathValue("webhook_id"), &webhookID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookDeliveries(w, r, webhookID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -818,22 +3390,58 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/formats", wrapper.GetFormats)
 	m.HandleFunc("POST "+options.BaseURL+"/jar", wrapper.CreateJar)
 	m.HandleFunc("DELETE "+options.BaseURL+"/jar/{id}", wrapper.DeleteJar)
 	m.HandleFunc("GET "+options.BaseURL+"/jar/{id}", wrapper.GetJar)
+	m.HandleFunc("GET "+options.BaseURL+"/jar/{id}/events", wrapper.GetJarEvents)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/fork", wrapper.ForkJar)
+	m.HandleFunc("GET "+options.BaseURL+"/jar/{id}/members", wrapper.GetJarMembers)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/members", wrapper.AddJarMember)
+	m.HandleFunc("DELETE "+options.BaseURL+"/jar/{id}/members/{user_id}", wrapper.RemoveJarMember)
+	m.HandleFunc("PUT "+options.BaseURL+"/jar/{id}/order", wrapper.ReorderJarScrolls)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/restore", wrapper.RestoreJar)
 	m.HandleFunc("GET "+options.BaseURL+"/jar/{id}/scrolls", wrapper.GetJarScrolls)
+	m.HandleFunc("GET "+options.BaseURL+"/jar/{id}/share-links", wrapper.GetShareLinks)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/share-links", wrapper.CreateShareLink)
+	m.HandleFunc("DELETE "+options.BaseURL+"/jar/{id}/share-links/{link_id}", wrapper.RevokeShareLink)
+	m.HandleFunc("PUT "+options.BaseURL+"/jar/{id}/slug", wrapper.SetJarSlug)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/transfer", wrapper.CreateJarTransfer)
+	m.HandleFunc("POST "+options.BaseURL+"/jar/{id}/unlock", wrapper.UnlockJar)
 	m.HandleFunc("GET "+options.BaseURL+"/ping", wrapper.Ping)
 	m.HandleFunc("DELETE "+options.BaseURL+"/scroll/{id}", wrapper.DeleteScroll)
 	m.HandleFunc("GET "+options.BaseURL+"/scroll/{id}", wrapper.GetScroll)
 	m.HandleFunc("PATCH "+options.BaseURL+"/scroll/{id}", wrapper.PatchScroll)
 	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}", wrapper.CreateScroll)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/append", wrapper.AppendScroll)
+	m.HandleFunc("GET "+options.BaseURL+"/scroll/{id}/comments", wrapper.GetScrollComments)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/comments", wrapper.CreateScrollComment)
+	m.HandleFunc("DELETE "+options.BaseURL+"/scroll/{id}/comments/{comment_id}", wrapper.DeleteScrollComment)
+	m.HandleFunc("PATCH "+options.BaseURL+"/scroll/{id}/comments/{comment_id}", wrapper.PatchScrollComment)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/copy", wrapper.CopyScroll)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/move", wrapper.MoveScroll)
+	m.HandleFunc("GET "+options.BaseURL+"/scroll/{id}/raw", wrapper.GetScrollRaw)
+	m.HandleFunc("GET "+options.BaseURL+"/scroll/{id}/render", wrapper.RenderScroll)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/restore", wrapper.RestoreScroll)
+	m.HandleFunc("POST "+options.BaseURL+"/scroll/{id}/seal", wrapper.SealScroll)
+	m.HandleFunc("GET "+options.BaseURL+"/scroll/{id}/tail", wrapper.TailScroll)
+	m.HandleFunc("GET "+options.BaseURL+"/search", wrapper.Search)
 	m.HandleFunc("POST "+options.BaseURL+"/token/activation", wrapper.CreateActivationToken)
+	m.HandleFunc("DELETE "+options.BaseURL+"/transfer/{transfer_id}", wrapper.DeleteJarTransfer)
+	m.HandleFunc("POST "+options.BaseURL+"/transfer/{transfer_id}/accept", wrapper.AcceptJarTransfer)
 	m.HandleFunc("PUT "+options.BaseURL+"/upload", wrapper.UploadScroll)
 	m.HandleFunc("GET "+options.BaseURL+"/user", wrapper.GetUser)
 	m.HandleFunc("PUT "+options.BaseURL+"/user/activate", wrapper.ActivateUser)
 	m.HandleFunc("POST "+options.BaseURL+"/user/auth", wrapper.AuthUser)
 	m.HandleFunc("GET "+options.BaseURL+"/user/jars", wrapper.GetUserJars)
 	m.HandleFunc("POST "+options.BaseURL+"/user/register", wrapper.CreateUser)
+	m.HandleFunc("GET "+options.BaseURL+"/user/transfers", wrapper.GetUserTransfers)
+	m.HandleFunc("GET "+options.BaseURL+"/user/trash", wrapper.GetUserTrash)
+	m.HandleFunc("GET "+options.BaseURL+"/user/webhooks", wrapper.GetUserWebhooks)
+	m.HandleFunc("POST "+options.BaseURL+"/webhook", wrapper.CreateWebhook)
+	m.HandleFunc("DELETE "+options.BaseURL+"/webhook/{webhook_id}", wrapper.DeleteWebhook)
+	m.HandleFunc("PATCH "+options.BaseURL+"/webhook/{webhook_id}", wrapper.PatchWebhook)
+	m.HandleFunc("GET "+options.BaseURL+"/webhook/{webhook_id}/deliveries", wrapper.GetWebhookDeliveries)
 
 	return m
 }
@@ -2,8 +2,14 @@ package spec
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/kapilpokhrel/scrolljar/internal/formats"
+	"github.com/kapilpokhrel/scrolljar/internal/render"
 )
 
 type ExpiryDuration struct {
@@ -34,12 +40,43 @@ func (input CreateJarInput) Validate(unlimitedExpiry bool) *Validator {
 	v.Check(AllFunc(input.Tags, func(tag string) bool {
 		return len(tag) < 50
 	}), "tags", "no tag can be of length greater than 50")
+	for i, scroll := range input.Scrolls {
+		validateScrollFormat(v, fmt.Sprintf("scrolls.%d.", i), scroll.Format, scroll.Filename)
+	}
 
 	DurYear := time.Hour * 25 * 365
 	v.Check(unlimitedExpiry || input.Expiry.Duration == nil || *(input.Expiry.Duration) < DurYear, "expiry", "Duration of anonymouns jar must be less than a yaer")
 	return v
 }
 
+func (input CreateScrollInput) Validate() *Validator {
+	v := NewValidator()
+	validateScrollFormat(v, "", input.Format, input.Filename)
+	return v
+}
+
+func (input ScrollPatchInput) Validate() *Validator {
+	v := NewValidator()
+	var format, filename string
+	if input.Format != nil {
+		format = *input.Format
+	}
+	if input.Filename != nil {
+		filename = *input.Filename
+	}
+	validateScrollFormat(v, "", format, filename)
+	return v
+}
+
+// validateScrollFormat checks that a scroll's format, if given, is registered
+// and that its filename is a bare file name. Keys are prefixed with prefix.
+func validateScrollFormat(v *Validator, prefix, format, filename string) {
+	_, known := formats.Lookup(format)
+	v.Check(format == "" || known, prefix+"format", "format must be one of the formats listed by /formats")
+	v.Check(len(filename) <= 255, prefix+"filename", "filename can't be longer than 255 bytes")
+	v.Check(!strings.ContainsAny(filename, "/\\\x00"), prefix+"filename", "filename can't contain slashes or NUL bytes")
+}
+
 func (input LoginInput) Validate() *Validator {
 	v := NewValidator()
 	v.Check(
@@ -85,3 +122,252 @@ func (input RegistrationInput) Validate() *Validator {
 
 	return v
 }
+
+func (params SearchParams) Validate() *Validator {
+	v := NewValidator()
+	v.Check(len(params.Q) > 0 && len(params.Q) <= 256, "q", "query must be within 1-256 characters")
+	v.Check(params.Limit >= 0 && params.Limit <= 100, "limit", "limit must be between 1 and 100")
+	v.Check(params.Offset >= 0, "offset", "offset can't be negative")
+	return v
+}
+
+func validatePage(v *Validator, limit int, order SortOrder) {
+	v.Check(limit >= 0 && limit <= 100, "limit", "limit must be between 1 and 100")
+	v.Check(order == "" || PermittedValue(order, SortAsc, SortDesc), "order", "order must be one of asc, desc")
+}
+
+func (params GetUserJarsParams) Validate() *Validator {
+	v := NewValidator()
+	validatePage(v, params.Limit, params.Order)
+	v.Check(params.Sort == "" || PermittedValue(params.Sort,
+		GetUserJarsParamsSortCreatedAt,
+		GetUserJarsParamsSortUpdatedAt,
+		GetUserJarsParamsSortExpiresAt,
+		GetUserJarsParamsSortName,
+	), "sort", "sort must be one of created_at, updated_at, expires_at, name")
+	v.Check(params.Access == nil || (*params.Access >= AccessPublic && *params.Access <= AccessPrivate), "access", "access type can be one of 0, 1")
+	return v
+}
+
+func (params GetJarScrollsParams) Validate() *Validator {
+	v := NewValidator()
+	validatePage(v, params.Limit, params.Order)
+	v.Check(params.Sort == "" || PermittedValue(params.Sort,
+		GetJarScrollsParamsSortPosition,
+		GetJarScrollsParamsSortCreatedAt,
+		GetJarScrollsParamsSortUpdatedAt,
+		GetJarScrollsParamsSortName,
+	), "sort", "sort must be one of position, created_at, updated_at, name")
+	return v
+}
+
+// LineRange is an inclusive range of 1-based line numbers. The zero value
+// covers every line.
+type LineRange struct {
+	Start, End int
+}
+
+var errInvalidLineRange = errors.New("invalid line range")
+
+// ParseLineRange parses a range such as 40-60, or 40 for a single line.
+func ParseLineRange(s string) (LineRange, error) {
+	startText, endText, isRange := strings.Cut(s, "-")
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 1 {
+		return LineRange{}, errInvalidLineRange
+	}
+	end := start
+	if isRange {
+		end, err = strconv.Atoi(endText)
+		if err != nil || end < start {
+			return LineRange{}, errInvalidLineRange
+		}
+	}
+	return LineRange{Start: start, End: end}, nil
+}
+
+// Contains reports whether line is within the range.
+func (lr LineRange) Contains(line int) bool {
+	return lr.Start == 0 || (line >= lr.Start && line <= lr.End)
+}
+
+// String formats the range the way ParseLineRange reads it, such as 40-60,
+// or 40 for a single line.
+func (lr LineRange) String() string {
+	if lr.Start == lr.End {
+		return strconv.Itoa(lr.Start)
+	}
+	return fmt.Sprintf("%d-%d", lr.Start, lr.End)
+}
+
+func validateLines(v *Validator, lines string) {
+	_, err := ParseLineRange(lines)
+	v.Check(lines == "" || err == nil, "lines", "lines must be a line number or a range of them such as 40-60")
+}
+
+// maxCommentLength is the most bytes a comment's body can have.
+const maxCommentLength = 10000
+
+func (input CreateScrollCommentInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check(input.LineStart >= 1, "line_start", "line_start must be a line number")
+	v.Check(input.LineEnd == 0 || input.LineEnd >= input.LineStart, "line_end", "line_end can't be before line_start")
+	v.Check(input.Revision >= 0, "revision", "revision can't be negative")
+	validateCommentBody(v, input.Body)
+	return v
+}
+
+func (input ScrollCommentPatchInput) Validate() *Validator {
+	v := NewValidator()
+	validateCommentBody(v, input.Body)
+	return v
+}
+
+func validateCommentBody(v *Validator, body string) {
+	v.Check(strings.TrimSpace(body) != "", "body", "body can't be empty")
+	v.Check(len(body) <= maxCommentLength, "body", fmt.Sprintf("body can't be longer than %d bytes", maxCommentLength))
+}
+
+func (params GetScrollRawParams) Validate() *Validator {
+	v := NewValidator()
+	validateLines(v, params.Lines)
+	return v
+}
+
+func (params RenderScrollParams) Validate() *Validator {
+	v := NewValidator()
+	validateLines(v, params.Lines)
+	v.Check(params.Theme == "" || render.HasTheme(params.Theme), "theme", "theme is not a known theme")
+	v.Check(params.Style == "" || PermittedValue(params.Style, RenderStyleClasses, RenderStyleInline), "style", "style must be one of classes, inline")
+	return v
+}
+
+func (params TailScrollParams) Validate() *Validator {
+	v := NewValidator()
+	_, err := params.LastOffset()
+	v.Check(err == nil, "Last-Event-ID", "Last-Event-ID must be the ID of an event from this stream")
+	return v
+}
+
+// LastOffset is the byte offset to resume tailing a scroll from, which is the
+// ID of the last event received, or 0 without one.
+func (params TailScrollParams) LastOffset() (int64, error) {
+	if params.LastEventID == "" {
+		return 0, nil
+	}
+	offset, err := strconv.ParseInt(params.LastEventID, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid event ID")
+	}
+	return offset, nil
+}
+
+func (input AddJarMemberInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check((input.Email == "") != (input.Username == ""), "email", "exactly one of email or username is required")
+	v.Check(input.Email == "" || Matches(string(input.Email), EmailReg), "email", "must be a valid email address")
+	v.Check(PermittedValue(input.Role, RoleViewer, RoleEditor), "role", "role can be one of viewer, editor")
+	return v
+}
+
+func (input CreateJarTransferInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check((input.Email == "") != (input.Username == ""), "email", "exactly one of email or username is required")
+	v.Check(input.Email == "" || Matches(string(input.Email), EmailReg), "email", "must be a valid email address")
+	return v
+}
+
+// reservedSlugs are words that would be confusing or misleading as jar slugs.
+var reservedSlugs = []string{
+	"admin", "api", "about", "help", "jar", "jars", "login", "logout", "new",
+	"ping", "register", "root", "scroll", "scrolls", "search", "settings",
+	"share", "static", "support", "swagger", "system", "token", "transfer",
+	"trash", "upload", "user", "users", "www",
+}
+
+func (input SetJarSlugInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check(Matches(input.Slug, SlugReg), "slug", "slug must be 3 to 64 lowercase letters, digits or hyphens, starting and ending with a letter or digit")
+	v.Check(!Matches(input.Slug, jarIDReg), "slug", "slugs of 8 letters and digits are reserved for jar IDs")
+	v.Check(!PermittedValue(input.Slug, reservedSlugs...), "slug", "slug is reserved")
+	return v
+}
+
+func (input ScrollDestinationInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check(input.JarID != "", "jarid", "jarid is required")
+	return v
+}
+
+func (input ReorderJarScrollsInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check(len(input.ScrollIds) > 0, "scroll_ids", "scroll_ids must not be empty")
+	v.Check(Unique(input.ScrollIds), "scroll_ids", "scroll_ids must not contain duplicates")
+	v.Check(!input.UpdatedAt.IsZero(), "updated_at", "updated_at is required")
+	return v
+}
+
+func (input CreateShareLinkInput) Validate() *Validator {
+	v := NewValidator()
+	v.Check(input.Expiry.Duration == nil || *input.Expiry.Duration >= time.Minute, "expiry", "expiry period must be at least a minute")
+	v.Check(input.Expiry.Duration == nil || *input.Expiry.Duration <= time.Hour*24*30, "expiry", "expiry period can't be longer than 30 days")
+	v.Check(input.MaxUses == nil || *input.MaxUses > 0, "max_uses", "max_uses must be greater than 0")
+	return v
+}
+
+func (input CreateWebhookInput) Validate() *Validator {
+	v := NewValidator()
+	validateWebhookURL(v, input.URL)
+	validateWebhookSecret(v, input.Secret)
+	validateWebhookEvents(v, input.Events)
+	return v
+}
+
+func (input WebhookPatchInput) Validate() *Validator {
+	v := NewValidator()
+	if input.URL != nil {
+		validateWebhookURL(v, *input.URL)
+	}
+	if input.Secret != nil {
+		validateWebhookSecret(v, *input.Secret)
+	}
+	if input.Events != nil {
+		validateWebhookEvents(v, *input.Events)
+	}
+	return v
+}
+
+func validateWebhookURL(v *Validator, rawURL string) {
+	u, err := url.Parse(rawURL)
+	v.Check(
+		err == nil && PermittedValue(u.Scheme, "http", "https") && u.Host != "" && u.User == nil,
+		"url", "url must be an absolute http or https URL without credentials",
+	)
+	v.Check(len(rawURL) <= 2048, "url", "url can't be longer than 2048 bytes")
+}
+
+func validateWebhookSecret(v *Validator, secret string) {
+	v.Check(len(secret) >= 16 && len(secret) <= 256, "secret", "secret must be 16 to 256 characters long")
+}
+
+func validateWebhookEvents(v *Validator, events []JarEventType) {
+	v.Check(AllFunc(events, func(event JarEventType) bool {
+		return PermittedValue(event,
+			JarEventScrollCreated,
+			JarEventScrollUploaded,
+			JarEventScrollUpdated,
+			JarEventScrollDeleted,
+			JarEventScrollRestored,
+			JarEventJarUpdated,
+			JarEventJarDeleted,
+			JarEventJarExpired,
+		)
+	}), "events", "events must be jar event types")
+	v.Check(Unique(events), "events", "events must not contain duplicates")
+}
+
+func (params GetWebhookDeliveriesParams) Validate() *Validator {
+	v := NewValidator()
+	v.Check(params.Limit >= 0 && params.Limit <= 100, "limit", "limit must be between 1 and 100")
+	return v
+}
@@ -0,0 +1,81 @@
+package spec
+
+import "testing"
+
+func TestParseLineRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    LineRange
+		wantErr bool
+	}{
+		{in: "40", want: LineRange{Start: 40, End: 40}},
+		{in: "40-60", want: LineRange{Start: 40, End: 60}},
+		{in: "1-1", want: LineRange{Start: 1, End: 1}},
+		{in: "07", want: LineRange{Start: 7, End: 7}},
+		{in: "40-", wantErr: true},
+		{in: "-60", wantErr: true},
+		{in: "60-40", wantErr: true},
+		{in: "0", wantErr: true},
+		{in: "0-10", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "a-b", wantErr: true},
+		{in: "40-60-80", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLineRange(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseLineRange(%q) = %+v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLineRange(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLineRange(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		// String must give back something that parses to the same range.
+		if back, err := ParseLineRange(got.String()); err != nil || back != got {
+			t.Errorf("ParseLineRange(%q).String() = %q doesn't round-trip", tt.in, got.String())
+		}
+	}
+}
+
+func TestLineRangeString(t *testing.T) {
+	tests := []struct {
+		lr   LineRange
+		want string
+	}{
+		{LineRange{Start: 40, End: 40}, "40"},
+		{LineRange{Start: 40, End: 60}, "40-60"},
+	}
+	for _, tt := range tests {
+		if got := tt.lr.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.lr, got, tt.want)
+		}
+	}
+}
+
+func TestLineRangeContains(t *testing.T) {
+	tests := []struct {
+		lr   LineRange
+		line int
+		want bool
+	}{
+		{LineRange{}, 1, true},
+		{LineRange{}, 1000, true},
+		{LineRange{Start: 40, End: 60}, 39, false},
+		{LineRange{Start: 40, End: 60}, 40, true},
+		{LineRange{Start: 40, End: 60}, 60, true},
+		{LineRange{Start: 40, End: 60}, 61, false},
+		{LineRange{Start: 7, End: 7}, 7, true},
+		{LineRange{Start: 7, End: 7}, 8, false},
+	}
+	for _, tt := range tests {
+		if got := tt.lr.Contains(tt.line); got != tt.want {
+			t.Errorf("%+v.Contains(%d) = %v, want %v", tt.lr, tt.line, got, tt.want)
+		}
+	}
+}
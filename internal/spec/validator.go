@@ -8,6 +8,14 @@ import (
 
 var EmailReg = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 
+// SlugReg matches 3 to 64 lowercase letters, digits and hyphens that start
+// and end with a letter or digit.
+var SlugReg = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}[a-z0-9]$`)
+
+// jarIDReg matches strings shaped like a generated jar ID, which slugs can't
+// take so that IDs and slugs never collide.
+var jarIDReg = regexp.MustCompile(`^[0-9A-Za-z]{8}$`)
+
 type Validator ValidationError
 
 func NewValidator() *Validator {
@@ -47,3 +55,14 @@ func AllFunc[E any](s []E, fn func(E) bool) bool {
 	}
 	return true
 }
+
+func Unique[E comparable](s []E) bool {
+	seen := make(map[E]struct{}, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+	}
+	return true
+}